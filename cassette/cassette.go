@@ -0,0 +1,300 @@
+// Package cassette records the HTTP requests a helpscout connection makes, and
+// their responses, to a file, and replays them later without the network, so
+// integration tests written against a real account can run offline
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces scrubbed secrets in cassettes
+const Redacted = "REDACTED"
+
+// Mode is whether a Recorder records or replays
+type Mode int
+
+// Modes
+const (
+	// ModeReplay serves responses from the cassette, failing any request
+	// that wasn't recorded. Nothing is sent over the network
+	ModeReplay Mode = iota
+
+	// ModeRecord sends requests over the network and
+	// overwrites the cassette with what happened
+	ModeRecord
+
+	// ModeAuto replays if the cassette exists, and records it if it doesn't
+	ModeAuto
+)
+
+// Request is a recorded request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a request and the response it got
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the contents of a cassette file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// scrubbedHeaders are the request headers that carry credentials
+var scrubbedHeaders = []string{"Authorization", "Cookie"}
+
+// scrubbedFormFields are the token request fields that carry credentials
+var scrubbedFormFields = []string{"client_id", "client_secret"}
+
+// accessTokenPattern matches access tokens in token responses
+var accessTokenPattern = regexp.MustCompile(`("(?:access|refresh)_token"\s*:\s*)"[^"]*"`)
+
+// Recorder is an http.RoundTripper that records to or replays from a
+// cassette file. Set it as a helpscout connection's Transport, or pass it
+// to helpscout.NewWithTransport so the first token request goes through it
+type Recorder struct {
+	// Transport sends requests while recording.
+	// http.DefaultTransport is used if it's nil
+	Transport http.RoundTripper
+
+	path     string
+	mode     Mode
+	secrets  []string
+	mtx      sync.Mutex
+	cassette Cassette
+	played   []bool
+}
+
+// New returns a Recorder for the cassette at path. In ModeReplay, or ModeAuto
+// when the file exists, the cassette is loaded immediately. Secrets, e.g. the
+// connection's AppSecret, are replaced with Redacted wherever they're recorded
+func New(path string, mode Mode, secrets ...string) (r *Recorder, err error) {
+	r = &Recorder{
+		path: path,
+		mode: mode,
+	}
+	for _, s := range secrets {
+		if len(s) != 0 {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+
+	if r.mode == ModeAuto {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %s", err)
+		}
+		err = json.Unmarshal(b, &r.cassette)
+		if err != nil {
+			return nil, fmt.Errorf("cassette %s: %s", path, err)
+		}
+		r.played = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return
+}
+
+// Recording reports whether requests are being sent over the network and recorded
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// RoundTrip records or replays a single request
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := r.scrubRequest(req, body)
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+	return r.record(req, recorded)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded Request) (*http.Response, error) {
+	for i, in := range r.cassette.Interactions {
+		if r.played[i] || !matches(in.Request, recorded) {
+			continue
+		}
+		r.played[i] = true
+
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no unplayed interaction for %s %s", r.path, recorded.Method, recorded.URL)
+}
+
+func (r *Recorder) record(req *http.Request, recorded Request) (*http.Response, error) {
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// The body's length can change when it's scrubbed
+	header := resp.Header.Clone()
+	header.Del("Content-Length")
+	for k, vs := range header {
+		for i, v := range vs {
+			header[k][i] = r.scrub(v)
+		}
+	}
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       r.scrub(accessTokenPattern.ReplaceAllString(string(body), `$1"`+Redacted+`"`)),
+		},
+	})
+
+	// Saving after every interaction keeps what was recorded
+	// even if the test fails or panics before it's done
+	err = r.save()
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *Recorder) save() error {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "\t")
+	err := enc.Encode(r.cassette)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, b.Bytes(), 0644)
+}
+
+// scrub replaces every secret in s with Redacted
+func (r *Recorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, Redacted, -1)
+	}
+	return s
+}
+
+// scrubRequest returns the request as it's recorded and matched,
+// with its credentials and secrets redacted
+func (r *Recorder) scrubRequest(req *http.Request, body []byte) Request {
+	header := req.Header.Clone()
+	for _, k := range scrubbedHeaders {
+		if len(header.Get(k)) != 0 {
+			header.Set(k, Redacted)
+		}
+	}
+	for k, vs := range header {
+		for i, v := range vs {
+			header[k][i] = r.scrub(v)
+		}
+	}
+
+	b := string(body)
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(b); err == nil {
+			for _, k := range scrubbedFormFields {
+				if _, ok := form[k]; ok {
+					form.Set(k, Redacted)
+				}
+			}
+			b = form.Encode()
+		}
+	}
+
+	return Request{
+		Method: req.Method,
+		URL:    r.scrub(req.URL.RequestURI()),
+		Header: header,
+		Body:   r.scrub(b),
+	}
+}
+
+// matches reports whether a recorded request is the same as a new one. The
+// method, path, and query must match exactly; bodies must match as JSON if
+// they're JSON, or exactly otherwise. Headers are ignored
+func matches(recorded Request, req Request) bool {
+	if recorded.Method != req.Method || recorded.URL != req.URL {
+		return false
+	}
+	if recorded.Body == req.Body {
+		return true
+	}
+
+	var a, b interface{}
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(req.Body), &b) != nil {
+		return false
+	}
+	aj, _ := json.Marshal(a)
+	bj, _ := json.Marshal(b)
+	return bytes.Equal(aj, bj)
+}
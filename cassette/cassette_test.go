@@ -0,0 +1,177 @@
+package cassette_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+	"github.com/StirlingMarketingGroup/go-helpscout/cassette"
+	"github.com/StirlingMarketingGroup/go-helpscout/helpscouttest"
+)
+
+// serverTransport sends requests meant for Help Scout to a helpscouttest server
+type serverTransport struct {
+	server *url.URL
+}
+
+func (t serverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.server.Scheme
+	req.URL.Host = t.server.Host
+	req.Host = ""
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+var newConversation = helpscout.CreateConversationRequest{
+	Subject:  "Where's my order?",
+	Customer: helpscout.Customer{Email: "jane.doe@example.com"},
+	Threads: []helpscout.NewThread{{
+		Type:     helpscout.ThreadTypeCustomer,
+		Customer: helpscout.Customer{Email: "jane.doe@example.com"},
+		Content:  "My order number is 12345",
+	}},
+}
+
+// session creates a conversation and lists the mailbox's conversations
+func session(h *helpscout.HelpScout, mailboxID int) (conversationID int, conversations []helpscout.Conversation, err error) {
+	h.SetMailboxID(mailboxID)
+	conversationID, _, err = h.CreateConversation(newConversation)
+	if err != nil {
+		return
+	}
+	conversations, err = h.ListConversations("")
+	return
+}
+
+// reorderBodies rewrites the cassette's JSON request bodies with their keys
+// sorted, so they only match new requests as JSON
+func reorderBodies(t *testing.T, path string) {
+	t.Helper()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c cassette.Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		t.Fatal(err)
+	}
+
+	reordered := 0
+	for i, in := range c.Interactions {
+		var body map[string]interface{}
+		if json.Unmarshal([]byte(in.Request.Body), &body) != nil {
+			continue
+		}
+		j, _ := json.Marshal(body)
+		if string(j) != in.Request.Body {
+			c.Interactions[i].Request.Body = string(j)
+			reordered++
+		}
+	}
+	if reordered == 0 {
+		t.Fatal("no JSON request body was reordered")
+	}
+
+	b, _ = json.Marshal(c)
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	s := helpscouttest.NewServer()
+	serverURL, _ := url.Parse(s.URL)
+	mailboxID := s.AddMailbox("Support", "support@example.com")
+
+	rec, err := cassette.New(path, cassette.ModeRecord, s.AppSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec.Transport = serverTransport{server: serverURL}
+	h, err := helpscout.NewWithTransport(s.AppID, s.AppSecret, rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := h.ReadAccessToken()
+	wantID, wantConversations, err := session(h, mailboxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{s.AppSecret, token} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("the cassette has %q in it", secret)
+		}
+	}
+
+	// The server's closed and the app secret is wrong, so
+	// only the cassette can answer the replayed requests
+	reorderBodies(t, path)
+	rec, err = cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err = helpscout.NewWithTransport(s.AppID, "wrong", rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, conversations, err := session(h, mailboxID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != wantID || len(conversations) != len(wantConversations) {
+		t.Errorf("replayed conversation %d and %d conversations, want %d and %d", id, len(conversations), wantID, len(wantConversations))
+	}
+
+	// Every interaction has been played
+	req, _ := http.NewRequest("GET", "https://api.helpscout.net/v2/conversations?status=all&mailbox="+strconv.Itoa(mailboxID)+"&page=1", nil)
+	if _, err := rec.RoundTrip(req); err == nil || !strings.Contains(err.Error(), "no unplayed interaction") {
+		t.Errorf("replaying a played request returned %v, want a no unplayed interaction error", err)
+	}
+}
+
+func TestReplayMatchesBodies(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conversation.json")
+	c := cassette.Cassette{Interactions: []cassette.Interaction{{
+		Request:  cassette.Request{Method: "POST", URL: "/v2/conversations", Body: `{"subject":"Hi","mailboxId":1}`},
+		Response: cassette.Response{StatusCode: http.StatusCreated},
+	}}}
+	b, _ := json.Marshal(c)
+	if err := ioutil.WriteFile(path, b, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec, err := cassette.New(path, cassette.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := func(body string) (*http.Response, error) {
+		req, _ := http.NewRequest("POST", "https://api.helpscout.net/v2/conversations", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return rec.RoundTrip(req)
+	}
+
+	if _, err := post(`{"subject":"Bye","mailboxId":1}`); err == nil || !strings.Contains(err.Error(), "no unplayed interaction") {
+		t.Errorf("a different body returned %v, want a no unplayed interaction error", err)
+	}
+	resp, err := post(`{ "mailboxId": 1, "subject": "Hi" }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		t.Errorf("status code = %d, want %d", resp.StatusCode, http.StatusCreated)
+	}
+}
@@ -0,0 +1,51 @@
+package helpscout
+
+// Conversations creates and lists conversations
+type Conversations interface {
+	CreateConversation(rq CreateConversationRequest) (conversationID int, resp []byte, err error)
+	CreateAndReloadConversation(rq CreateConversationRequest) (conversation Conversation, resp []byte, err error)
+	ListConversations(query string) (conversations []Conversation, err error)
+	ListConversationsByEmail(email string) (conversations []Conversation, err error)
+}
+
+// Threads creates, lists, and edits the threads of conversations
+type Threads interface {
+	CreateThread(conversationID int, thread NewThread) (threadID int, err error)
+	GetThreads(conversationID int) (threads []Thread, err error)
+	UpdateThreadText(conversationID int, thread Thread, text string) (err error)
+	HideThread(conversationID int, thread Thread) (err error)
+	UnhideThread(conversationID int, thread Thread) (err error)
+}
+
+// CustomFields reads the current mailbox's custom fields
+// and sets their values on conversations
+type CustomFields interface {
+	ListCustomFields() (fields RsListMailboxCustomFields, err error)
+	GetCustomFieldIDByName(name string) (customerFieldID int, err error)
+	UpdateCustomFields(conversationID int, fields map[string]interface{}) (err error)
+}
+
+// Mailboxes selects the mailbox other operations work in
+type Mailboxes interface {
+	SetMailboxID(id int)
+	DeselectMailbox()
+	SelectMailbox(mailbox interface{}) error
+}
+
+// Attachments uploads files to threads
+type Attachments interface {
+	UploadAttachment(conversationID int, threadID int, name string, mimeType string, data []byte) (resp []byte, err error)
+}
+
+// Client is everything a Help Scout connection can do with conversations and
+// their mailboxes. Depend on it instead of *HelpScout so tests can substitute
+// helpscouttest.Fake
+type Client interface {
+	Conversations
+	Threads
+	CustomFields
+	Mailboxes
+	Attachments
+}
+
+var _ Client = (*HelpScout)(nil)
@@ -0,0 +1,47 @@
+package helpscout
+
+import "time"
+
+// Clock tells the time and waits for it to pass. Setting a connection's
+// clock lets tests control its logging, rate limiting, retry backoff,
+// and access token expiry without waiting. Connections on the same clock
+// share a rate limit, so clocks must be comparable, e.g. pointers
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the real time
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SetClock sets the clock the connection uses for logging, rate limiting,
+// retry backoff, and access token expiry. The current access token keeps
+// however long it had left on the old clock
+func (h *HelpScout) SetClock(c Clock) {
+	old := h.clock()
+
+	h.accessTokenMtx.Lock()
+	if !h.accessTokenExpiry.IsZero() {
+		h.accessTokenExpiry = c.Now().Add(h.accessTokenExpiry.Sub(old.Now()))
+	}
+	h.clk = c
+	h.accessTokenMtx.Unlock()
+}
+
+// clock returns the connection's clock, which is SystemClock unless SetClock was called
+func (h *HelpScout) clock() Clock {
+	if h.clk != nil {
+		return h.clk
+	}
+	return SystemClock
+}
@@ -3,30 +3,23 @@ package helpscout
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 )
 
-const (
-	reqPhotoUnknown       = "unknown"
-	reqPhotoGravatar      = "gravatar"
-	reqPhotoTwitter       = "twitter"
-	reqPhotoFacebook      = "facebook"
-	reqPhotoGoogleProfile = "googleprofile"
-	reqPhotoGooglePlus    = "googleplus"
-	reqPhotoLinkedIn      = "linkedin"
-)
-
-const (
-	reqGenderMale    = "male"
-	reqGenderFemale  = "female"
-	reqGenderUnknown = "unknown"
-)
+// formatISO8601 is the timestamp layout Help Scout expects in requests
+const formatISO8601 = "2006-01-02T15:04:05Z"
 
 // Time is the same as time.Time, but marshals with time.RFC3339
 type Time time.Time
 
+// String formats Time the same way it's marshalled, for use in query strings
+func (t Time) String() string {
+	return time.Time(t).Format(formatISO8601)
+}
+
 // MarshalJSON marshalls Time with time.RFC3339
 func (t Time) MarshalJSON() ([]byte, error) {
 	if y := time.Time(t).Year(); y < 0 || y >= 10000 {
@@ -35,7 +28,6 @@ func (t Time) MarshalJSON() ([]byte, error) {
 		return nil, errors.New("Time.MarshalJSON: year outside of range [0,9999]")
 	}
 
-	formatISO8601 := "2006-01-02T15:04:05Z"
 	b := make([]byte, 0, len(formatISO8601)+2)
 	b = append(b, '"')
 	b = time.Time(t).AppendFormat(b, formatISO8601)
@@ -43,84 +35,284 @@ func (t Time) MarshalJSON() ([]byte, error) {
 	return b, nil
 }
 
+// UnmarshalJSON unmarshalls Time from the RFC 3339 timestamps in Help Scout responses
+func (t *Time) UnmarshalJSON(b []byte) error {
+	var tt time.Time
+	err := tt.UnmarshalJSON(b)
+	if err != nil {
+		return err
+	}
+	*t = Time(tt)
+	return nil
+}
+
 // Customer is a customer object, as defined by Help Scout
 // The use of pointers for everything here is important
 // so that we can omit some values instead of sending blank strings
 type Customer struct {
-	ID        int    `json:"id,omitempty"`
-	Email     string `json:"email,omitempty"`
-	FirstName string `json:"firstName,omitempty"`
-	LastName  string `json:"lastName,omitempty"`
-	PhotoURL  string `json:"photoUrl,omitempty"`
-	JobTitle  string `json:"jobTitle,omitempty"`
-	PhotoType string `json:"photoType,omitempty"`
-	Notes     string `json:"background,omitempty"`
-	Location  string `json:"location,omitempty"`
-	Created   *Time  `json:"createdAt,omitempty"`
-	Company   string `json:"organization,omitempty"`
-	Gender    string `json:"gender,omitempty"`
-	Age       string `json:"age,omitempty"`
+	ID        int       `json:"id,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	FirstName string    `json:"firstName,omitempty"`
+	LastName  string    `json:"lastName,omitempty"`
+	PhotoURL  string    `json:"photoUrl,omitempty"`
+	JobTitle  string    `json:"jobTitle,omitempty"`
+	PhotoType PhotoType `json:"photoType,omitempty"`
+	Notes     string    `json:"background,omitempty"`
+	Location  string    `json:"location,omitempty"`
+	Created   *Time     `json:"createdAt,omitempty"`
+	Company   string    `json:"organization,omitempty"`
+	// OrganizationID links the customer to an Organization,
+	// unlike Company, which is free text
+	OrganizationID int    `json:"organizationId,omitempty"`
+	Gender         Gender `json:"gender,omitempty"`
+	Age            string `json:"age,omitempty"`
+}
+
+// CreateConversationRequest is a request for creating a conversation.
+// MailboxID defaults to the current mailbox, Type to "email", and Status
+// to "active". Cc and Bcc are set per thread, on NewThread
+// https://developer.helpscout.com/mailbox-api/endpoints/conversations/create/
+type CreateConversationRequest struct {
+	Subject   string                `json:"subject"`
+	Customer  Customer              `json:"customer"`
+	MailboxID int                   `json:"mailboxId"`
+	Type      ConversationType      `json:"type"`
+	Status    ConversationStatus    `json:"status"`
+	AssignTo  int                   `json:"assignTo,omitempty"`
+	User      int                   `json:"user,omitempty"`
+	AutoReply bool                  `json:"autoReply,omitempty"`
+	Imported  bool                  `json:"imported"`
+	Tags      []string              `json:"tags,omitempty"`
+	Fields    []RqUpdateCustomField `json:"fields,omitempty"`
+	Created   *Time                 `json:"createdAt,omitempty"`
+	Closed    *Time                 `json:"closedAt,omitempty"`
+	Threads   []NewThread           `json:"threads"`
+}
+
+// creatableConversationStatuses are the statuses a conversation can be created with
+var creatableConversationStatuses = map[ConversationStatus]bool{
+	ConversationStatusActive:  true,
+	ConversationStatusPending: true,
+	ConversationStatusClosed:  true,
+}
+
+// CreateConversation creates a new conversation from the given request and returns the new Conversation ID
+func (h *HelpScout) CreateConversation(rq CreateConversationRequest) (conversationID int, resp []byte, err error) {
+	header, resp, err := h.createConversation(rq, nil)
+	if err != nil {
+		return
+	}
+
+	conversationID, _ = strconv.Atoi(header.Get("Resource-ID"))
+	return
+}
+
+// CreateAndReloadConversation creates a new conversation from the given request
+// and returns it as saved, including its threads, without an extra request
+func (h *HelpScout) CreateAndReloadConversation(rq CreateConversationRequest) (conversation Conversation, resp []byte, err error) {
+	_, resp, err = h.createConversation(rq, &conversation)
+	return
+}
+
+// createConversation validates and sends the given request. If dest isn't nil,
+// Help Scout is asked to reload the created conversation into it
+func (h *HelpScout) createConversation(rq CreateConversationRequest, dest *Conversation) (header http.Header, resp []byte, err error) {
+	if rq.MailboxID == 0 {
+		rq.MailboxID = h.MailboxID
+	}
+	rq.SetDefaults()
+	err = rq.Validate()
+	if err != nil {
+		return
+	}
+
+	if dest == nil {
+		_, header, resp, err = h.Exec("conversations", &rq, nil, "")
+	} else {
+		_, header, resp, err = h.Exec("conversations?reload=true", &rq, dest, "")
+	}
+	return
+}
+
+// SetDefaults fills in the request's blank type and status
+func (rq *CreateConversationRequest) SetDefaults() {
+	if len(rq.Type) == 0 {
+		rq.Type = ConversationTypeEmail
+	}
+	if len(rq.Status) == 0 {
+		rq.Status = ConversationStatusActive
+	}
+}
+
+// Validate checks the request the same way CreateConversation does before
+// sending it, after SetDefaults. It doesn't check the mailbox ID
+func (rq CreateConversationRequest) Validate() error {
+	if len(rq.Subject) == 0 {
+		return fmt.Errorf("subjects cannot be blank")
+	}
+	if len(rq.Threads) == 0 {
+		return fmt.Errorf("conversations need at least one thread")
+	}
+	if !rq.Type.Valid() {
+		return fmt.Errorf("%q isn't a valid conversation type", rq.Type)
+	}
+	if !creatableConversationStatuses[rq.Status] {
+		return fmt.Errorf("%q isn't a valid conversation status", rq.Status)
+	}
+	if rq.Closed != nil && rq.Status != ConversationStatusClosed {
+		return fmt.Errorf("only closed conversations can have a closed time")
+	}
+	err := rq.validateThreads()
+	if err != nil {
+		return err
+	}
+	return rq.validateLive()
+}
+
+// conversationThreadTypes are the thread types each type of conversation can be created with
+var conversationThreadTypes = map[ConversationType]map[ThreadType]bool{
+	ConversationTypeEmail: {ThreadTypeCustomer: true, ThreadTypeReply: true, ThreadTypeNote: true},
+	ConversationTypePhone: {ThreadTypePhone: true, ThreadTypeReply: true, ThreadTypeNote: true},
+	ConversationTypeChat:  {ThreadTypeChat: true, ThreadTypeReply: true, ThreadTypeNote: true},
+}
+
+// validateThreads checks that the threads fit the type of conversation being created
+func (rq CreateConversationRequest) validateThreads() error {
+	for i, t := range rq.Threads {
+		if !conversationThreadTypes[rq.Type][t.Type] {
+			return fmt.Errorf("thread %d is a %q thread, which %s conversations can't have", i, t.Type, rq.Type)
+		}
+		switch t.Type {
+		case ThreadTypePhone, ThreadTypeChat:
+			if t.Customer.ID == 0 && len(t.Customer.Email) == 0 && rq.Customer.ID == 0 && len(rq.Customer.Email) == 0 {
+				return fmt.Errorf("%s threads need a customer ID or email", t.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// validateLive checks that live, non-imported conversations have what Help Scout
+// needs to actually send email, and that imported ones don't try to
+func (rq CreateConversationRequest) validateLive() error {
+	if rq.Imported {
+		if rq.AutoReply {
+			return fmt.Errorf("imported conversations can't send auto replies")
+		}
+		return nil
+	}
+
+	for i, t := range rq.Threads {
+		if t.Imported {
+			return fmt.Errorf("thread %d is imported, but the conversation is live", i)
+		}
+		if t.Type != ThreadTypeReply || t.Draft {
+			continue
+		}
+		if len(rq.Customer.Email) == 0 && len(t.Customer.Email) == 0 {
+			return fmt.Errorf("live replies need a customer email to send to")
+		}
+		if rq.User == 0 && t.User == 0 {
+			return fmt.Errorf("live replies need a user to send from")
+		}
+	}
+	return nil
+}
+
+// NewOutboundConversation creates a live email conversation that starts with a
+// reply from the given user, which Help Scout emails to the customer
+func (h *HelpScout) NewOutboundConversation(subject string, customer Customer, user int, tags []string, content string) (conversation Conversation, resp []byte, err error) {
+	return h.CreateAndReloadConversation(CreateConversationRequest{
+		Subject:  subject,
+		Customer: customer,
+		Type:     ConversationTypeEmail,
+		User:     user,
+		Tags:     tags,
+		Threads: []NewThread{{
+			Type:     ThreadTypeReply,
+			Customer: Customer{Email: customer.Email},
+			Content:  content,
+			Created:  Time(h.clock().Now().UTC()),
+			User:     user,
+		}},
+	})
 }
 
-type reqConversation struct {
-	Subject   string      `json:"subject"`
-	Customer  Customer    `json:"customer"`
-	MailboxID int         `json:"mailboxId"`
-	Type      string      `json:"type"`
-	Status    string      `json:"status"`
-	Created   *Time       `json:"createdAt"`
-	Threads   []NewThread `json:"threads"`
-	Imported  bool        `json:"imported"`
-	Tags      []string    `json:"tags"`
-	Closed    *Time       `json:"closedAt"`
-	User      int         `json:"user,omitempty"`
+// NewLiveConversationWithMessage creates a live conversation from a message the
+// customer sent, optionally sending the mailbox's auto reply to them
+func (h *HelpScout) NewLiveConversationWithMessage(subject string, customer Customer, tags []string, content string, autoReply bool) (conversation Conversation, resp []byte, err error) {
+	if autoReply && len(customer.Email) == 0 {
+		return conversation, nil, fmt.Errorf("auto replies need a customer email to send to")
+	}
+
+	return h.CreateAndReloadConversation(CreateConversationRequest{
+		Subject:   subject,
+		Customer:  customer,
+		Type:      ConversationTypeEmail,
+		AutoReply: autoReply,
+		Tags:      tags,
+		Threads: []NewThread{{
+			Type:     ThreadTypeCustomer,
+			Customer: customer,
+			Content:  content,
+			Created:  Time(h.clock().Now().UTC()),
+		}},
+	})
 }
 
 // NewConversationWithMessage creates a new message thread from the
 // given customer in the current mailbox
 func (h *HelpScout) NewConversationWithMessage(subject string, customer Customer, created time.Time, tags []string, content string, searchForThreadID bool, closed bool, user int) (conversationID int, threadID int, resp []byte, err error) {
-	return h.NewConversationWithThread("customer", subject, customer, created, tags, content, searchForThreadID, closed, user)
+	return h.NewConversationWithThread(string(ThreadTypeCustomer), subject, customer, created, tags, content, searchForThreadID, closed, user)
 }
 
 // NewConversationWithReply creates a reply thread to the given customer
 func (h *HelpScout) NewConversationWithReply(subject string, customer Customer, created time.Time, tags []string, content string, searchForThreadID bool, closed bool, user int) (conversationID int, threadID int, resp []byte, err error) {
-	return h.NewConversationWithThread("reply", subject, customer, created, tags, content, searchForThreadID, closed, user)
+	return h.NewConversationWithThread(string(ThreadTypeReply), subject, customer, created, tags, content, searchForThreadID, closed, user)
 }
 
 // NewConversationWithThread creates a conversation and a thread with the given customer information
 func (h *HelpScout) NewConversationWithThread(threadType string, subject string, customer Customer, created time.Time, tags []string, content string, searchForThreadID bool, closed bool, user int) (conversationID int, threadID int, resp []byte, err error) {
-	conversationID, resp, err = h.NewConversation(subject, customer, created, tags, []NewThread{{
-		Type:     threadType,
+	rq := newImportedConversationRequest(subject, customer, created, tags, []NewThread{{
+		Type:     ThreadType(threadType),
 		Customer: customer,
 		Content:  content,
 		Imported: true,
 		Created:  Time(created.UTC()),
 	}}, closed, user)
-	if err != nil {
+	if !searchForThreadID {
+		conversationID, resp, err = h.CreateConversation(rq)
 		return
 	}
 
-	// This can be disabled since getting the thread ID takes extra API requests,
-	// unlike getting the conversation ID, which is returned with the conversation
-	// creation request
-	if searchForThreadID {
-		threadID, err = h.GetLatestThreadID(conversationID)
+	// Reloading returns the conversation's threads with the creation request,
+	// so getting the thread ID doesn't take an extra API request
+	conversation, resp, err := h.CreateAndReloadConversation(rq)
+	if err != nil {
+		return
+	}
+	conversationID = conversation.ID
+	if len(conversation.Embedded.Threads) == 0 {
+		return conversationID, 0, resp, fmt.Errorf("conversation %d was created without any threads", conversationID)
 	}
+	threadID = conversation.Embedded.Threads[0].ID
 
 	return
 }
 
-// NewConversation creates a new conversation with the given customer and returns the new Conversation ID
+// NewConversation creates a new imported email conversation with the given customer and returns the new Conversation ID.
+// Use CreateConversation for anything else
 func (h *HelpScout) NewConversation(subject string, customer Customer, created time.Time, tags []string, threads []NewThread, closed bool, user int) (conversationID int, resp []byte, err error) {
-	if len(subject) == 0 {
-		return 0, nil, fmt.Errorf("subjects cannot be blank")
-	}
+	return h.CreateConversation(newImportedConversationRequest(subject, customer, created, tags, threads, closed, user))
+}
 
-	var status string
+// newImportedConversationRequest builds the request the positional NewConversation helpers send
+func newImportedConversationRequest(subject string, customer Customer, created time.Time, tags []string, threads []NewThread, closed bool, user int) CreateConversationRequest {
+	var status ConversationStatus
 	if closed {
-		status = "closed"
+		status = ConversationStatusClosed
 	} else {
-		status = "active"
+		status = ConversationStatusActive
 	}
 
 	closedTime := new(Time)
@@ -134,25 +326,18 @@ func (h *HelpScout) NewConversation(subject string, customer Customer, created t
 	*createdTime = Time(created.UTC())
 
 	customer.Created = createdTime
-	_, header, resp, err := h.Exec("conversations", &reqConversation{
-		Subject:   subject,
-		Customer:  customer,
-		MailboxID: h.MailboxID,
-		Type:      "email",
-		Status:    status,
-		Created:   createdTime,
-		Threads:   threads,
-		Imported:  true,
-		Tags:      tags,
-		Closed:    closedTime,
-		User:      user,
-	}, nil, "")
-	if err != nil {
-		return
+	return CreateConversationRequest{
+		Subject:  subject,
+		Customer: customer,
+		Type:     ConversationTypeEmail,
+		Status:   status,
+		Created:  createdTime,
+		Threads:  threads,
+		Imported: true,
+		Tags:     tags,
+		Closed:   closedTime,
+		User:     user,
 	}
-
-	conversationID, _ = strconv.Atoi(header.Get("Resource-ID"))
-	return
 }
 
 // RsListConversations is a list conversations response
@@ -160,43 +345,22 @@ type RsListConversations struct {
 	Embedded struct {
 		Conversations []Conversation `json:"conversations"`
 	} `json:"_embedded"`
-	Links struct {
-		First struct {
-			Href string `json:"href"`
-		} `json:"first"`
-		Last struct {
-			Href string `json:"href"`
-		} `json:"last"`
-		Next struct {
-			Href string `json:"href"`
-		} `json:"next"`
-		Page struct {
-			Href string `json:"href"`
-		} `json:"page"`
-		Self struct {
-			Href string `json:"href"`
-		} `json:"self"`
-	} `json:"_links"`
-	Page struct {
-		Size          int `json:"size"`
-		TotalElements int `json:"totalElements"`
-		TotalPages    int `json:"totalPages"`
-		Number        int `json:"number"`
-	} `json:"page"`
+	Links ListLinks `json:"_links"`
+	Page  PageInfo  `json:"page"`
 }
 
 // Conversation is a Help Scout conversation
 type Conversation struct {
-	ID        int    `json:"id"`
-	Number    int    `json:"number"`
-	Threads   int    `json:"threads"`
-	Type      string `json:"type"`
-	FolderID  int    `json:"folderId"`
-	Status    string `json:"status"`
-	State     string `json:"state"`
-	Subject   string `json:"subject"`
-	Preview   string `json:"preview"`
-	MailboxID int    `json:"mailboxId"`
+	ID        int                `json:"id"`
+	Number    int                `json:"number"`
+	Threads   int                `json:"threads"`
+	Type      ConversationType   `json:"type"`
+	FolderID  int                `json:"folderId"`
+	Status    ConversationStatus `json:"status"`
+	State     string             `json:"state"`
+	Subject   string             `json:"subject"`
+	Preview   string             `json:"preview"`
+	MailboxID int                `json:"mailboxId"`
 	CreatedBy struct {
 		ID       int    `json:"id"`
 		Type     string `json:"type"`
@@ -228,7 +392,15 @@ type Conversation struct {
 		Email    string `json:"email"`
 	} `json:"primaryCustomer"`
 	CustomFields []interface{} `json:"customFields"`
-	Links        struct {
+	Embedded     struct {
+		Threads []Thread `json:"threads"`
+	} `json:"_embedded"`
+	Snooze struct {
+		SnoozedBy               int       `json:"snoozedBy"`
+		SnoozedUntil            time.Time `json:"snoozedUntil"`
+		UnsnoozeOnCustomerReply bool      `json:"unsnoozeOnCustomerReply"`
+	} `json:"snooze"`
+	Links struct {
 		ClosedBy struct {
 			Href string `json:"href"`
 		} `json:"closedBy"`
@@ -287,3 +459,40 @@ func (h *HelpScout) ListConversations(query string) (conversations []Conversatio
 func (h *HelpScout) ListConversationsByEmail(email string) (conversations []Conversation, err error) {
 	return h.ListConversations(`(email:"` + email + `")`)
 }
+
+// IsSnoozed reports whether the conversation is currently snoozed,
+// by the system clock. Use IsSnoozedAt with a connection's Clock
+func (c Conversation) IsSnoozed() bool {
+	return c.IsSnoozedAt(time.Now())
+}
+
+// IsSnoozedAt reports whether the conversation is snoozed at the given time
+func (c Conversation) IsSnoozedAt(t time.Time) bool {
+	return c.Snooze.SnoozedUntil.After(t)
+}
+
+type reqSnooze struct {
+	SnoozedUntil            Time `json:"snoozedUntil"`
+	UnsnoozeOnCustomerReply bool `json:"unsnoozeOnCustomerReply"`
+}
+
+// SnoozeConversation snoozes the given conversation until the given time.
+// If unsnoozeOnCustomerReply is true, a reply from the customer ends the snooze early
+// https://developer.helpscout.com/mailbox-api/endpoints/conversations/snooze/
+func (h *HelpScout) SnoozeConversation(conversationID int, until time.Time, unsnoozeOnCustomerReply bool) (err error) {
+	if !until.After(h.clock().Now()) {
+		return fmt.Errorf("conversations can only be snoozed until a time in the future")
+	}
+
+	_, _, _, err = h.Exec("conversations/"+strconv.Itoa(conversationID)+"/snooze", reqSnooze{
+		SnoozedUntil:            Time(until.UTC()),
+		UnsnoozeOnCustomerReply: unsnoozeOnCustomerReply,
+	}, nil, "PUT")
+	return
+}
+
+// UnsnoozeConversation ends the given conversation's snooze early
+func (h *HelpScout) UnsnoozeConversation(conversationID int) (err error) {
+	_, _, _, err = h.Exec("conversations/"+strconv.Itoa(conversationID)+"/snooze", nil, nil, "DELETE")
+	return
+}
@@ -0,0 +1,160 @@
+package helpscout
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	cache "github.com/patrickmn/go-cache"
+)
+
+// CustomerProperty is the definition of an account-wide customer property.
+// Type is one of "text", "number", "date", "dropdown", or "url"
+// https://developer.helpscout.com/mailbox-api/endpoints/customer-properties/list/
+type CustomerProperty struct {
+	Type    string `json:"type"`
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Options []struct {
+		ID    string `json:"id"`
+		Label string `json:"label"`
+	} `json:"options"`
+}
+
+// CustomerPropertyValue is the value of a customer property on a single customer.
+// Text is the value as shown in Help Scout, e.g. a dropdown option's label
+type CustomerPropertyValue struct {
+	Type  string      `json:"type"`
+	Slug  string      `json:"slug"`
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+	Text  string      `json:"text"`
+}
+
+// RsListCustomerProperties is a customer properties response
+type RsListCustomerProperties = Page[CustomerProperty]
+
+var getCustomerPropertiesCache = cache.New(10*time.Second, 20*time.Second)
+
+// ListCustomerProperties returns all the account's customer property definitions
+func (h *HelpScout) ListCustomerProperties() (properties []CustomerProperty, err error) {
+	key := h.AppID + ":ListCustomerProperties"
+	v, found := getCustomerPropertiesCache.Get(key)
+	if found {
+		return v.([]CustomerProperty), nil
+	}
+
+	properties, err = ListAll[CustomerProperty](h, "customer-properties")
+	if err != nil {
+		return
+	}
+	getCustomerPropertiesCache.Set(key, properties, cache.DefaultExpiration)
+
+	return
+}
+
+// GetCustomerPropertyBySlug gets a customer property definition by slug
+func (h *HelpScout) GetCustomerPropertyBySlug(slug string) (property CustomerProperty, err error) {
+	properties, err := h.ListCustomerProperties()
+	if err != nil {
+		return
+	}
+
+	for _, p := range properties {
+		if p.Slug == slug {
+			return p, nil
+		}
+	}
+
+	return property, fmt.Errorf("couldn't find the customer property %q", slug)
+}
+
+// GetCustomerProperties returns the given customer's property values
+func (h *HelpScout) GetCustomerProperties(customerID int) (values []CustomerPropertyValue, err error) {
+	var rs struct {
+		Properties []CustomerPropertyValue `json:"properties"`
+	}
+	_, _, _, err = h.Exec("customers/"+strconv.Itoa(customerID), nil, &rs, "")
+	if err != nil {
+		return
+	}
+	return rs.Properties, nil
+}
+
+// customerPropertyValue checks that v is valid for the given property
+// and returns it in the form Help Scout expects
+func customerPropertyValue(p CustomerProperty, v interface{}) (interface{}, error) {
+	switch p.Type {
+	case "text":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "number":
+		switch reflect.ValueOf(v).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return v, nil
+		}
+	case "date":
+		switch t := v.(type) {
+		case time.Time:
+			return t.Format("2006-01-02"), nil
+		case Time:
+			return time.Time(t).Format("2006-01-02"), nil
+		case string:
+			if _, err := time.Parse("2006-01-02", t); err != nil {
+				return nil, fmt.Errorf("customer property %q needs a YYYY-MM-DD date, got %q", p.Slug, t)
+			}
+			return t, nil
+		}
+	case "dropdown":
+		if s, ok := v.(string); ok {
+			for _, o := range p.Options {
+				if o.ID == s || o.Label == s {
+					return o.ID, nil
+				}
+			}
+			return nil, fmt.Errorf("%q isn't an option of customer property %q", s, p.Slug)
+		}
+	case "url":
+		if s, ok := v.(string); ok {
+			u, err := url.Parse(s)
+			if err != nil || len(u.Scheme) == 0 || len(u.Host) == 0 {
+				return nil, fmt.Errorf("customer property %q needs an absolute URL, got %q", p.Slug, s)
+			}
+			return s, nil
+		}
+	default:
+		return nil, fmt.Errorf("customer property %q has unknown type %q", p.Slug, p.Type)
+	}
+
+	return nil, fmt.Errorf("%Ts aren't valid values for %s customer property %q", v, p.Type, p.Slug)
+}
+
+// UpdateCustomerProperties sets the given property values, keyed by slug,
+// on the given customer. A nil value removes the property from the customer
+func (h *HelpScout) UpdateCustomerProperties(customerID int, values map[string]interface{}) (err error) {
+	patch := NewPatch(PatchCustomerProperties)
+	for slug, v := range values {
+		p, err := h.GetCustomerPropertyBySlug(slug)
+		if err != nil {
+			return err
+		}
+
+		if v == nil {
+			patch.Remove("/" + slug)
+			continue
+		}
+
+		value, err := customerPropertyValue(p, v)
+		if err != nil {
+			return err
+		}
+		patch.Replace("/"+slug, value)
+	}
+
+	return h.ApplyPatch("customers/"+strconv.Itoa(customerID)+"/properties", patch)
+}
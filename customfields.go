@@ -8,43 +8,28 @@ import (
 	cache "github.com/patrickmn/go-cache"
 )
 
+// CustomField is a mailbox custom field definition
+type CustomField struct {
+	ID       int    `json:"id"`
+	Required bool   `json:"required"`
+	Order    int    `json:"order"`
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Options  []struct {
+		ID    int    `json:"id"`
+		Order int    `json:"order"`
+		Label string `json:"label"`
+	} `json:"options"`
+}
+
 // RsListMailboxCustomFields is a mailbox custom fields response
 // https://developer.helpscout.com/mailbox-api/endpoints/mailboxes/mailbox-fields/
 type RsListMailboxCustomFields struct {
 	Embedded struct {
-		Fields []struct {
-			ID       int    `json:"id"`
-			Required bool   `json:"required"`
-			Order    int    `json:"order"`
-			Type     string `json:"type"`
-			Name     string `json:"name"`
-			Options  []struct {
-				ID    int    `json:"id"`
-				Order int    `json:"order"`
-				Label string `json:"label"`
-			} `json:"options"`
-		} `json:"fields"`
+		Fields []CustomField `json:"fields"`
 	} `json:"_embedded"`
-	Links struct {
-		First struct {
-			Href string `json:"href"`
-		} `json:"first"`
-		Last struct {
-			Href string `json:"href"`
-		} `json:"last"`
-		Page struct {
-			Href string `json:"href"`
-		} `json:"page"`
-		Self struct {
-			Href string `json:"href"`
-		} `json:"self"`
-	} `json:"_links"`
-	Page struct {
-		Size          int `json:"size"`
-		TotalElements int `json:"totalElements"`
-		TotalPages    int `json:"totalPages"`
-		Number        int `json:"number"`
-	} `json:"page"`
+	Links ListLinks `json:"_links"`
+	Page  PageInfo  `json:"page"`
 }
 
 var getCustomFieldsCache = cache.New(10*time.Second, 20*time.Second)
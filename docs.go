@@ -0,0 +1,521 @@
+package helpscout
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Docs is a Help Scout Docs API connection instance.
+// It shares RawExec's retries, rate limiting, and logging with the
+// Mailbox API, but authenticates with an API key instead of OAuth
+// https://developer.helpscout.com/docs-api/
+type Docs struct {
+	h *HelpScout
+}
+
+// NewDocs returns a new Help Scout Docs instance for the given API key
+func NewDocs(apiKey string) (d *Docs, err error) {
+	if len(apiKey) == 0 {
+		return nil, fmt.Errorf("docs API keys cannot be blank")
+	}
+
+	return &Docs{h: &HelpScout{
+		ConnNum:    getNextConnNum(),
+		docsAPIKey: apiKey,
+	}}, nil
+}
+
+// ConnNum returns the connection number used in logging
+func (d *Docs) ConnNum() int {
+	return d.h.ConnNum
+}
+
+// SetLogger sets the logger the connection logs to, the way
+// HelpScout.Logger does for the Mailbox API
+func (d *Docs) SetLogger(l *slog.Logger) {
+	d.h.Logger = l
+}
+
+// SetRedactor sets what's masked in the connection's logs,
+// the way HelpScout.Redactor does for the Mailbox API
+func (d *Docs) SetRedactor(r *Redactor) {
+	d.h.Redactor = r
+}
+
+// Exec sends a request to the Docs API, the same way HelpScout.Exec
+// does for the Mailbox API
+func (d *Docs) Exec(u string, v interface{}, dest interface{}, method string) (r interface{}, header http.Header, resp []byte, err error) {
+	r, _, header, resp, err = d.h.RawExec(u, v, dest, method, true, false)
+	if err != nil {
+		return nil, nil, resp, err
+	}
+	return
+}
+
+// DocsPage is the paging information of Docs API list responses
+type DocsPage struct {
+	Page  int `json:"page"`
+	Pages int `json:"pages"`
+	Count int `json:"count"`
+}
+
+// DocsSite is a Docs site
+type DocsSite struct {
+	ID            string     `json:"id,omitempty"`
+	Status        string     `json:"status,omitempty"`
+	SubDomain     string     `json:"subDomain,omitempty"`
+	CNAME         string     `json:"cname,omitempty"`
+	HasPublicSite bool       `json:"hasPublicSite,omitempty"`
+	CompanyName   string     `json:"companyName,omitempty"`
+	Title         string     `json:"title,omitempty"`
+	LogoURL       string     `json:"logoUrl,omitempty"`
+	FavIconURL    string     `json:"favIconUrl,omitempty"`
+	HomeURL       string     `json:"homeUrl,omitempty"`
+	HomeLinkText  string     `json:"homeLinkText,omitempty"`
+	BgColor       string     `json:"bgColor,omitempty"`
+	Description   string     `json:"description,omitempty"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt     *time.Time `json:"updatedAt,omitempty"`
+}
+
+type respDocsSites struct {
+	Sites struct {
+		DocsPage
+		Items []DocsSite `json:"items"`
+	} `json:"sites"`
+}
+
+// ListSites returns every Docs site
+// https://developer.helpscout.com/docs-api/sites/list/
+func (d *Docs) ListSites() (sites []DocsSite, err error) {
+	var rs respDocsSites
+	page := 1
+	for {
+		rs = respDocsSites{}
+		_, _, _, err = d.Exec("sites?page="+strconv.Itoa(page), nil, &rs, "")
+		if err != nil {
+			return nil, err
+		}
+		sites = append(sites, rs.Sites.Items...)
+
+		if page >= rs.Sites.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// GetSite returns the Docs site with the given ID
+func (d *Docs) GetSite(siteID string) (site DocsSite, err error) {
+	var rs struct {
+		Site DocsSite `json:"site"`
+	}
+	_, _, _, err = d.Exec("sites/"+siteID, nil, &rs, "")
+	return rs.Site, err
+}
+
+// DocsCollection is a Docs collection, the top level grouping of articles
+type DocsCollection struct {
+	ID                    string     `json:"id,omitempty"`
+	SiteID                string     `json:"siteId,omitempty"`
+	Number                int        `json:"number,omitempty"`
+	Slug                  string     `json:"slug,omitempty"`
+	Visibility            string     `json:"visibility,omitempty"`
+	Order                 int        `json:"order,omitempty"`
+	Name                  string     `json:"name,omitempty"`
+	Description           string     `json:"description,omitempty"`
+	PublicURL             string     `json:"publicUrl,omitempty"`
+	ArticleCount          int        `json:"articleCount,omitempty"`
+	PublishedArticleCount int        `json:"publishedArticleCount,omitempty"`
+	CreatedBy             int        `json:"createdBy,omitempty"`
+	UpdatedBy             int        `json:"updatedBy,omitempty"`
+	CreatedAt             *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt             *time.Time `json:"updatedAt,omitempty"`
+}
+
+type respDocsCollections struct {
+	Collections struct {
+		DocsPage
+		Items []DocsCollection `json:"items"`
+	} `json:"collections"`
+}
+
+// ListCollections returns every collection in the given site,
+// or in every site if siteID is blank
+// https://developer.helpscout.com/docs-api/collections/list/
+func (d *Docs) ListCollections(siteID string) (collections []DocsCollection, err error) {
+	query := url.Values{}
+	if len(siteID) != 0 {
+		query.Set("siteId", siteID)
+	}
+
+	var rs respDocsCollections
+	page := 1
+	for {
+		rs = respDocsCollections{}
+		query.Set("page", strconv.Itoa(page))
+		_, _, _, err = d.Exec("collections?"+query.Encode(), nil, &rs, "")
+		if err != nil {
+			return nil, err
+		}
+		collections = append(collections, rs.Collections.Items...)
+
+		if page >= rs.Collections.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// GetCollection returns the collection with the given ID
+func (d *Docs) GetCollection(collectionID string) (collection DocsCollection, err error) {
+	var rs struct {
+		Collection DocsCollection `json:"collection"`
+	}
+	_, _, _, err = d.Exec("collections/"+collectionID, nil, &rs, "")
+	return rs.Collection, err
+}
+
+// NewCollection creates a collection and returns it as saved
+func (d *Docs) NewCollection(collection DocsCollection) (created DocsCollection, err error) {
+	if len(collection.SiteID) == 0 || len(collection.Name) == 0 {
+		return created, fmt.Errorf("collections need a site ID and a name")
+	}
+
+	var rs struct {
+		Collection DocsCollection `json:"collection"`
+	}
+	_, _, _, err = d.Exec("collections?reload=true", collection, &rs, "POST")
+	return rs.Collection, err
+}
+
+// UpdateCollection updates the collection with the given collection's ID
+func (d *Docs) UpdateCollection(collection DocsCollection) (err error) {
+	if len(collection.ID) == 0 {
+		return fmt.Errorf("collection ID cannot be blank")
+	}
+
+	_, _, _, err = d.Exec("collections/"+collection.ID, collection, nil, "PUT")
+	return
+}
+
+// DeleteCollection deletes the collection with the given ID
+func (d *Docs) DeleteCollection(collectionID string) (err error) {
+	_, _, _, err = d.Exec("collections/"+collectionID, nil, nil, "DELETE")
+	return
+}
+
+// DocsCategory is a Docs category, a grouping of articles within a collection
+type DocsCategory struct {
+	ID           string     `json:"id,omitempty"`
+	Number       int        `json:"number,omitempty"`
+	Slug         string     `json:"slug,omitempty"`
+	Visibility   string     `json:"visibility,omitempty"`
+	CollectionID string     `json:"collectionId,omitempty"`
+	Order        int        `json:"order,omitempty"`
+	DefaultSort  string     `json:"defaultSort,omitempty"`
+	Name         string     `json:"name,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	ArticleCount int        `json:"articleCount,omitempty"`
+	CreatedBy    int        `json:"createdBy,omitempty"`
+	UpdatedBy    int        `json:"updatedBy,omitempty"`
+	CreatedAt    *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
+
+type respDocsCategories struct {
+	Categories struct {
+		DocsPage
+		Items []DocsCategory `json:"items"`
+	} `json:"categories"`
+}
+
+// ListCategories returns every category in the given collection
+// https://developer.helpscout.com/docs-api/categories/list/
+func (d *Docs) ListCategories(collectionID string) (categories []DocsCategory, err error) {
+	var rs respDocsCategories
+	page := 1
+	for {
+		rs = respDocsCategories{}
+		_, _, _, err = d.Exec("collections/"+collectionID+"/categories?page="+strconv.Itoa(page), nil, &rs, "")
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, rs.Categories.Items...)
+
+		if page >= rs.Categories.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// GetCategory returns the category with the given ID
+func (d *Docs) GetCategory(categoryID string) (category DocsCategory, err error) {
+	var rs struct {
+		Category DocsCategory `json:"category"`
+	}
+	_, _, _, err = d.Exec("categories/"+categoryID, nil, &rs, "")
+	return rs.Category, err
+}
+
+// NewCategory creates a category and returns it as saved
+func (d *Docs) NewCategory(category DocsCategory) (created DocsCategory, err error) {
+	if len(category.CollectionID) == 0 || len(category.Name) == 0 {
+		return created, fmt.Errorf("categories need a collection ID and a name")
+	}
+
+	var rs struct {
+		Category DocsCategory `json:"category"`
+	}
+	_, _, _, err = d.Exec("categories?reload=true", category, &rs, "POST")
+	return rs.Category, err
+}
+
+// UpdateCategory updates the category with the given category's ID
+func (d *Docs) UpdateCategory(category DocsCategory) (err error) {
+	if len(category.ID) == 0 {
+		return fmt.Errorf("category ID cannot be blank")
+	}
+
+	_, _, _, err = d.Exec("categories/"+category.ID, category, nil, "PUT")
+	return
+}
+
+// DeleteCategory deletes the category with the given ID
+func (d *Docs) DeleteCategory(categoryID string) (err error) {
+	_, _, _, err = d.Exec("categories/"+categoryID, nil, nil, "DELETE")
+	return
+}
+
+// DocsArticle is a Docs article. Listing and searching articles only
+// fills in the summary fields; use GetArticle for the text
+type DocsArticle struct {
+	ID              string     `json:"id,omitempty"`
+	Number          int        `json:"number,omitempty"`
+	CollectionID    string     `json:"collectionId,omitempty"`
+	SiteID          string     `json:"siteId,omitempty"`
+	Status          string     `json:"status,omitempty"`
+	Slug            string     `json:"slug,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	Text            string     `json:"text,omitempty"`
+	Preview         string     `json:"preview,omitempty"`
+	Categories      []string   `json:"categories,omitempty"`
+	Related         []string   `json:"related,omitempty"`
+	Keywords        []string   `json:"keywords,omitempty"`
+	PublicURL       string     `json:"publicUrl,omitempty"`
+	Popularity      float64    `json:"popularity,omitempty"`
+	ViewCount       int        `json:"viewCount,omitempty"`
+	HasDraft        bool       `json:"hasDraft,omitempty"`
+	LastPublishedAt *time.Time `json:"lastPublishedAt,omitempty"`
+	CreatedBy       int        `json:"createdBy,omitempty"`
+	UpdatedBy       int        `json:"updatedBy,omitempty"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
+}
+
+type respDocsArticles struct {
+	Articles struct {
+		DocsPage
+		Items []DocsArticle `json:"items"`
+	} `json:"articles"`
+}
+
+// listArticles returns every article on every page of the given list endpoint
+func (d *Docs) listArticles(u string, query url.Values) (articles []DocsArticle, err error) {
+	var rs respDocsArticles
+	page := 1
+	for {
+		rs = respDocsArticles{}
+		query.Set("page", strconv.Itoa(page))
+		_, _, _, err = d.Exec(u+"?"+query.Encode(), nil, &rs, "")
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, rs.Articles.Items...)
+
+		if page >= rs.Articles.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// ListCollectionArticles returns every article in the given collection.
+// status filters by "all", "published", or "notpublished"; blank means all
+// https://developer.helpscout.com/docs-api/articles/list/
+func (d *Docs) ListCollectionArticles(collectionID string, status string) (articles []DocsArticle, err error) {
+	query := url.Values{}
+	if len(status) != 0 {
+		query.Set("status", status)
+	}
+	return d.listArticles("collections/"+collectionID+"/articles", query)
+}
+
+// ListCategoryArticles returns every article in the given category.
+// status filters by "all", "published", or "notpublished"; blank means all
+func (d *Docs) ListCategoryArticles(categoryID string, status string) (articles []DocsArticle, err error) {
+	query := url.Values{}
+	if len(status) != 0 {
+		query.Set("status", status)
+	}
+	return d.listArticles("categories/"+categoryID+"/articles", query)
+}
+
+// RqSearchArticles is a request for searching articles.
+// Query is required; everything else narrows the search
+// https://developer.helpscout.com/docs-api/articles/search/
+type RqSearchArticles struct {
+	Query        string
+	SiteID       string
+	CollectionID string
+	Status       string
+	Visibility   string
+}
+
+// SearchArticles returns every article matching the given search
+func (d *Docs) SearchArticles(rq RqSearchArticles) (articles []DocsArticle, err error) {
+	if len(rq.Query) == 0 {
+		return nil, fmt.Errorf("search queries cannot be blank")
+	}
+
+	query := url.Values{"query": {rq.Query}}
+	if len(rq.SiteID) != 0 {
+		query.Set("siteId", rq.SiteID)
+	}
+	if len(rq.CollectionID) != 0 {
+		query.Set("collectionId", rq.CollectionID)
+	}
+	if len(rq.Status) != 0 {
+		query.Set("status", rq.Status)
+	}
+	if len(rq.Visibility) != 0 {
+		query.Set("visibility", rq.Visibility)
+	}
+	return d.listArticles("search/articles", query)
+}
+
+// GetArticle returns the article with the given ID or number
+func (d *Docs) GetArticle(articleID string) (article DocsArticle, err error) {
+	var rs struct {
+		Article DocsArticle `json:"article"`
+	}
+	_, _, _, err = d.Exec("articles/"+articleID, nil, &rs, "")
+	return rs.Article, err
+}
+
+// NewArticle creates an article and returns it as saved
+func (d *Docs) NewArticle(article DocsArticle) (created DocsArticle, err error) {
+	if len(article.CollectionID) == 0 || len(article.Name) == 0 || len(article.Text) == 0 {
+		return created, fmt.Errorf("articles need a collection ID, a name, and text")
+	}
+
+	var rs struct {
+		Article DocsArticle `json:"article"`
+	}
+	_, _, _, err = d.Exec("articles?reload=true", article, &rs, "POST")
+	return rs.Article, err
+}
+
+// UpdateArticle updates the article with the given article's ID
+func (d *Docs) UpdateArticle(article DocsArticle) (err error) {
+	if len(article.ID) == 0 {
+		return fmt.Errorf("article ID cannot be blank")
+	}
+
+	_, _, _, err = d.Exec("articles/"+article.ID, article, nil, "PUT")
+	return
+}
+
+// DeleteArticle deletes the article with the given ID
+func (d *Docs) DeleteArticle(articleID string) (err error) {
+	_, _, _, err = d.Exec("articles/"+articleID, nil, nil, "DELETE")
+	return
+}
+
+// DocsRedirect is a redirect from an old URL on a site to an article or URL
+type DocsRedirect struct {
+	ID         string `json:"id,omitempty"`
+	SiteID     string `json:"siteId,omitempty"`
+	URLMapping string `json:"urlMapping,omitempty"`
+	Redirect   string `json:"redirect,omitempty"`
+}
+
+type respDocsRedirects struct {
+	Redirects struct {
+		DocsPage
+		Items []DocsRedirect `json:"items"`
+	} `json:"redirects"`
+}
+
+// ListRedirects returns every redirect on the given site
+// https://developer.helpscout.com/docs-api/redirects/list/
+func (d *Docs) ListRedirects(siteID string) (redirects []DocsRedirect, err error) {
+	var rs respDocsRedirects
+	page := 1
+	for {
+		rs = respDocsRedirects{}
+		_, _, _, err = d.Exec("redirects/site/"+siteID+"?page="+strconv.Itoa(page), nil, &rs, "")
+		if err != nil {
+			return nil, err
+		}
+		redirects = append(redirects, rs.Redirects.Items...)
+
+		if page >= rs.Redirects.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// GetRedirect returns the redirect with the given ID
+func (d *Docs) GetRedirect(redirectID string) (redirect DocsRedirect, err error) {
+	var rs struct {
+		Redirect DocsRedirect `json:"redirect"`
+	}
+	_, _, _, err = d.Exec("redirects/"+redirectID, nil, &rs, "")
+	return rs.Redirect, err
+}
+
+// NewRedirect creates a redirect and returns it as saved
+func (d *Docs) NewRedirect(redirect DocsRedirect) (created DocsRedirect, err error) {
+	if len(redirect.SiteID) == 0 || len(redirect.URLMapping) == 0 || len(redirect.Redirect) == 0 {
+		return created, fmt.Errorf("redirects need a site ID, a URL mapping, and a redirect")
+	}
+
+	var rs struct {
+		Redirect DocsRedirect `json:"redirect"`
+	}
+	_, _, _, err = d.Exec("redirects?reload=true", redirect, &rs, "POST")
+	return rs.Redirect, err
+}
+
+// UpdateRedirect updates the redirect with the given redirect's ID
+func (d *Docs) UpdateRedirect(redirect DocsRedirect) (err error) {
+	if len(redirect.ID) == 0 {
+		return fmt.Errorf("redirect ID cannot be blank")
+	}
+
+	_, _, _, err = d.Exec("redirects/"+redirect.ID, redirect, nil, "PUT")
+	return
+}
+
+// DeleteRedirect deletes the redirect with the given ID
+func (d *Docs) DeleteRedirect(redirectID string) (err error) {
+	_, _, _, err = d.Exec("redirects/"+redirectID, nil, nil, "DELETE")
+	return
+}
@@ -0,0 +1,59 @@
+package helpscout
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// NewDraftReply creates a draft reply to the given customer from the given
+// user on an existing conversation and returns the draft's thread ID.
+// Drafts aren't sent until they're published
+func (h *HelpScout) NewDraftReply(conversationID int, customer Customer, user int, text string) (threadID int, err error) {
+	if customer.ID == 0 && len(customer.Email) == 0 {
+		return 0, fmt.Errorf("draft replies need a customer ID or email")
+	}
+
+	return h.CreateThread(conversationID, NewThread{
+		Type:     ThreadTypeReply,
+		Customer: Customer{ID: customer.ID, Email: customer.Email},
+		Content:  text,
+		Created:  Time(h.clock().Now().UTC()),
+		Draft:    true,
+		User:     user,
+	})
+}
+
+// ListDrafts returns the draft threads on the given conversation
+func (h *HelpScout) ListDrafts(conversationID int) (drafts []Thread, err error) {
+	threads, err := h.GetThreads(conversationID)
+	if err != nil {
+		return
+	}
+
+	for _, t := range threads {
+		if t.State == ThreadStateDraft {
+			drafts = append(drafts, t)
+		}
+	}
+	return
+}
+
+// UpdateDraft replaces the text of the given draft
+func (h *HelpScout) UpdateDraft(conversationID int, threadID int, text string) (err error) {
+	if len(text) == 0 {
+		return fmt.Errorf("draft text cannot be blank")
+	}
+
+	return h.patchThread(conversationID, threadID, "/text", text)
+}
+
+// PublishDraft publishes the given draft, sending it to the customer
+func (h *HelpScout) PublishDraft(conversationID int, threadID int) (err error) {
+	return h.patchThread(conversationID, threadID, "/draft", false)
+}
+
+// DiscardDraft deletes the given draft without sending it
+func (h *HelpScout) DiscardDraft(conversationID int, threadID int) (err error) {
+	_, _, _, err = h.Exec("conversations/"+strconv.Itoa(conversationID)+"/threads/"+strconv.Itoa(threadID), nil, nil, "DELETE")
+	return
+}
@@ -0,0 +1,145 @@
+package helpscout
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+)
+
+// EmailAttachment is a file attached to an original email
+type EmailAttachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Inline      bool
+	Data        []byte
+}
+
+// EmailSource is the parsed original MIME source of an email thread
+type EmailSource struct {
+	Raw         []byte
+	Header      mail.Header
+	Text        string
+	HTML        string
+	Attachments []EmailAttachment
+}
+
+// ParseEmailSource parses a raw MIME email into its headers,
+// text and HTML bodies, and attachments
+func ParseEmailSource(raw []byte) (source *EmailSource, err error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("helpscout parse email source: %s", err)
+	}
+
+	source = &EmailSource{
+		Raw:    raw,
+		Header: msg.Header,
+	}
+
+	err = source.parsePart(msg.Header.Get("Content-Type"), msg.Header.Get("Content-Transfer-Encoding"), msg.Header.Get("Content-Disposition"), msg.Header.Get("Content-ID"), msg.Body)
+	if err != nil {
+		return nil, fmt.Errorf("helpscout parse email source: %s", err)
+	}
+
+	return source, nil
+}
+
+// parsePart adds a single MIME part to the source, recursing into multipart parts
+func (s *EmailSource) parsePart(contentType string, encoding string, disposition string, contentID string, body io.Reader) (err error) {
+	if len(contentType) == 0 {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			err = s.parsePart(p.Header.Get("Content-Type"), p.Header.Get("Content-Transfer-Encoding"), p.Header.Get("Content-Disposition"), p.Header.Get("Content-ID"), p)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	switch strings.ToLower(encoding) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, newlineStripper{body})
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return
+	}
+
+	var dispType string
+	var dispParams map[string]string
+	if len(disposition) != 0 {
+		dispType, dispParams, _ = mime.ParseMediaType(disposition)
+	}
+	filename := dispParams["filename"]
+	if len(filename) == 0 {
+		filename = params["name"]
+	}
+
+	if dispType != "attachment" && len(filename) == 0 {
+		switch mediaType {
+		case "text/plain":
+			s.Text += string(data)
+			return
+		case "text/html":
+			s.HTML += string(data)
+			return
+		}
+	}
+
+	s.Attachments = append(s.Attachments, EmailAttachment{
+		Filename:    filename,
+		ContentType: mediaType,
+		ContentID:   strings.Trim(contentID, "<>"),
+		Inline:      dispType == "inline",
+		Data:        data,
+	})
+	return
+}
+
+// newlineStripper drops line breaks so base64 bodies wrapped at
+// 76 characters decode cleanly
+type newlineStripper struct {
+	r io.Reader
+}
+
+func (n newlineStripper) Read(p []byte) (int, error) {
+	for {
+		c, err := n.r.Read(p)
+		j := 0
+		for _, b := range p[:c] {
+			if b != '\r' && b != '\n' {
+				p[j] = b
+				j++
+			}
+		}
+		if j != 0 || err != nil {
+			return j, err
+		}
+	}
+}
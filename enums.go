@@ -0,0 +1,231 @@
+package helpscout
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StrictEnums being set to true makes marshalling and unmarshalling the enum
+// types below fail on values this package doesn't know about, instead of
+// passing them through. Blank values are always allowed
+var StrictEnums = false
+
+func marshalEnum(kind string, v string, valid bool) ([]byte, error) {
+	if StrictEnums && len(v) != 0 && !valid {
+		return nil, fmt.Errorf("%q isn't a valid %s", v, kind)
+	}
+	return json.Marshal(v)
+}
+
+func unmarshalEnum(kind string, b []byte, valid func(string) bool) (string, error) {
+	var v string
+	err := json.Unmarshal(b, &v)
+	if err != nil {
+		return "", err
+	}
+	if StrictEnums && len(v) != 0 && !valid(v) {
+		return "", fmt.Errorf("%q isn't a valid %s", v, kind)
+	}
+	return v, nil
+}
+
+// ConversationType is the channel a conversation came in through
+type ConversationType string
+
+// Conversation types
+const (
+	ConversationTypeEmail ConversationType = "email"
+	ConversationTypePhone ConversationType = "phone"
+	ConversationTypeChat  ConversationType = "chat"
+)
+
+// Valid reports whether t is a known conversation type
+func (t ConversationType) Valid() bool {
+	switch t {
+	case ConversationTypeEmail, ConversationTypePhone, ConversationTypeChat:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals t, rejecting unknown types in strict mode
+func (t ConversationType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("conversation type", string(t), t.Valid())
+}
+
+// UnmarshalJSON unmarshals t, rejecting unknown types in strict mode
+func (t *ConversationType) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("conversation type", b, func(v string) bool { return ConversationType(v).Valid() })
+	*t = ConversationType(v)
+	return err
+}
+
+// ConversationStatus is the status of a conversation
+type ConversationStatus string
+
+// Conversation statuses
+const (
+	ConversationStatusActive  ConversationStatus = "active"
+	ConversationStatusPending ConversationStatus = "pending"
+	ConversationStatusClosed  ConversationStatus = "closed"
+	ConversationStatusSpam    ConversationStatus = "spam"
+)
+
+// Valid reports whether s is a known conversation status
+func (s ConversationStatus) Valid() bool {
+	switch s {
+	case ConversationStatusActive, ConversationStatusPending, ConversationStatusClosed, ConversationStatusSpam:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals s, rejecting unknown statuses in strict mode
+func (s ConversationStatus) MarshalJSON() ([]byte, error) {
+	return marshalEnum("conversation status", string(s), s.Valid())
+}
+
+// UnmarshalJSON unmarshals s, rejecting unknown statuses in strict mode
+func (s *ConversationStatus) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("conversation status", b, func(v string) bool { return ConversationStatus(v).Valid() })
+	*s = ConversationStatus(v)
+	return err
+}
+
+// ThreadType is the kind of a thread within a conversation
+type ThreadType string
+
+// Thread types. Line items, forwards, and messages are created by Help Scout itself
+const (
+	ThreadTypeCustomer      ThreadType = "customer"
+	ThreadTypeReply         ThreadType = "reply"
+	ThreadTypeNote          ThreadType = "note"
+	ThreadTypeChat          ThreadType = "chat"
+	ThreadTypeBeaconChat    ThreadType = "beaconchat"
+	ThreadTypePhone         ThreadType = "phone"
+	ThreadTypeMessage       ThreadType = "message"
+	ThreadTypeLineItem      ThreadType = "lineitem"
+	ThreadTypeForwardParent ThreadType = "forwardparent"
+	ThreadTypeForwardChild  ThreadType = "forwardchild"
+)
+
+// Valid reports whether t is a known thread type
+func (t ThreadType) Valid() bool {
+	switch t {
+	case ThreadTypeCustomer, ThreadTypeReply, ThreadTypeNote, ThreadTypeChat, ThreadTypeBeaconChat,
+		ThreadTypePhone, ThreadTypeMessage, ThreadTypeLineItem, ThreadTypeForwardParent, ThreadTypeForwardChild:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals t, rejecting unknown types in strict mode
+func (t ThreadType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("thread type", string(t), t.Valid())
+}
+
+// UnmarshalJSON unmarshals t, rejecting unknown types in strict mode
+func (t *ThreadType) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("thread type", b, func(v string) bool { return ThreadType(v).Valid() })
+	*t = ThreadType(v)
+	return err
+}
+
+// ThreadState is the state of a thread
+type ThreadState string
+
+// Thread states
+const (
+	ThreadStatePublished   ThreadState = "published"
+	ThreadStateDraft       ThreadState = "draft"
+	ThreadStateUnderReview ThreadState = "underreview"
+	ThreadStateHidden      ThreadState = "hidden"
+)
+
+// Valid reports whether s is a known thread state
+func (s ThreadState) Valid() bool {
+	switch s {
+	case ThreadStatePublished, ThreadStateDraft, ThreadStateUnderReview, ThreadStateHidden:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals s, rejecting unknown states in strict mode
+func (s ThreadState) MarshalJSON() ([]byte, error) {
+	return marshalEnum("thread state", string(s), s.Valid())
+}
+
+// UnmarshalJSON unmarshals s, rejecting unknown states in strict mode
+func (s *ThreadState) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("thread state", b, func(v string) bool { return ThreadState(v).Valid() })
+	*s = ThreadState(v)
+	return err
+}
+
+// PhotoType is where a customer's photo came from
+type PhotoType string
+
+// Photo types
+const (
+	PhotoTypeUnknown       PhotoType = "unknown"
+	PhotoTypeGravatar      PhotoType = "gravatar"
+	PhotoTypeTwitter       PhotoType = "twitter"
+	PhotoTypeFacebook      PhotoType = "facebook"
+	PhotoTypeGoogleProfile PhotoType = "googleprofile"
+	PhotoTypeGooglePlus    PhotoType = "googleplus"
+	PhotoTypeLinkedIn      PhotoType = "linkedin"
+)
+
+// Valid reports whether t is a known photo type
+func (t PhotoType) Valid() bool {
+	switch t {
+	case PhotoTypeUnknown, PhotoTypeGravatar, PhotoTypeTwitter, PhotoTypeFacebook,
+		PhotoTypeGoogleProfile, PhotoTypeGooglePlus, PhotoTypeLinkedIn:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals t, rejecting unknown types in strict mode
+func (t PhotoType) MarshalJSON() ([]byte, error) {
+	return marshalEnum("photo type", string(t), t.Valid())
+}
+
+// UnmarshalJSON unmarshals t, rejecting unknown types in strict mode
+func (t *PhotoType) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("photo type", b, func(v string) bool { return PhotoType(v).Valid() })
+	*t = PhotoType(v)
+	return err
+}
+
+// Gender is a customer's gender
+type Gender string
+
+// Genders
+const (
+	GenderMale    Gender = "male"
+	GenderFemale  Gender = "female"
+	GenderUnknown Gender = "unknown"
+)
+
+// Valid reports whether g is a known gender
+func (g Gender) Valid() bool {
+	switch g {
+	case GenderMale, GenderFemale, GenderUnknown:
+		return true
+	}
+	return false
+}
+
+// MarshalJSON marshals g, rejecting unknown genders in strict mode
+func (g Gender) MarshalJSON() ([]byte, error) {
+	return marshalEnum("gender", string(g), g.Valid())
+}
+
+// UnmarshalJSON unmarshals g, rejecting unknown genders in strict mode
+func (g *Gender) UnmarshalJSON(b []byte) error {
+	v, err := unmarshalEnum("gender", b, func(v string) bool { return Gender(v).Valid() })
+	*g = Gender(v)
+	return err
+}
@@ -0,0 +1,131 @@
+package helpscout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Link is a HAL link from a response's _links
+type Link struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+// Links are a response's HAL links, keyed by relation, e.g. "self" or "next"
+type Links map[string]Link
+
+// Href returns the href of the link with the given relation
+func (l Links) Href(rel string) (href string, ok bool) {
+	link, ok := l[rel]
+	if !ok || len(link.Href) == 0 {
+		return "", false
+	}
+	return link.Href, true
+}
+
+// ListLinks are the HAL links of a list response's pages
+type ListLinks struct {
+	First Link `json:"first"`
+	Last  Link `json:"last"`
+	Prev  Link `json:"prev"`
+	Next  Link `json:"next"`
+	Page  Link `json:"page"`
+	Self  Link `json:"self"`
+}
+
+// PageInfo is the paging information of a list response
+type PageInfo struct {
+	Size          int `json:"size"`
+	TotalElements int `json:"totalElements"`
+	TotalPages    int `json:"totalPages"`
+	Number        int `json:"number"`
+}
+
+// Page is a single page of a HAL list response. Help Scout embeds the
+// items under a key named after the resource, e.g. "conversations"
+type Page[T any] struct {
+	Embedded map[string][]T `json:"_embedded"`
+	Links    Links          `json:"_links"`
+	Page     PageInfo       `json:"page"`
+}
+
+// Items returns the items embedded in the page. It fails if more than one
+// kind of item is embedded, since it can't tell which one was listed
+func (p Page[T]) Items() (items []T, err error) {
+	if len(p.Embedded) > 1 {
+		keys := make([]string, 0, len(p.Embedded))
+		for k := range p.Embedded {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("page embeds more than one list: %s", strings.Join(keys, ", "))
+	}
+	for _, embedded := range p.Embedded {
+		return embedded, nil
+	}
+	return nil, nil
+}
+
+// HasNext reports whether there's another page after this one
+func (p Page[T]) HasNext() bool {
+	_, ok := p.Links.Href("next")
+	return ok
+}
+
+// relativeURL turns an absolute href from a response into a URL for Exec,
+// dropping any URI template, e.g. "{?page}"
+func (h *HelpScout) relativeURL(href string) (u string, err error) {
+	if i := strings.Index(href, "{"); i != -1 {
+		href = href[:i]
+	}
+
+	base := h.baseURL()
+	if !strings.HasPrefix(href, base) {
+		return "", fmt.Errorf("%q isn't a link to %s", href, base)
+	}
+	return strings.TrimPrefix(href, base), nil
+}
+
+// Follow dereferences a HAL link href from any response, e.g. a conversation's
+// threads or primary customer, into dest
+func (h *HelpScout) Follow(href string, dest interface{}) (err error) {
+	u, err := h.relativeURL(href)
+	if err != nil {
+		return
+	}
+
+	_, _, _, err = h.Exec(u, nil, dest, "")
+	return
+}
+
+// ListAll returns the items on every page of the given list endpoint,
+// following each page's next link
+func ListAll[T any](h *HelpScout, u string) (items []T, err error) {
+	for {
+		p, _, err := Get[Page[T]](context.Background(), h, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		pageItems, err := p.Items()
+		if err != nil {
+			return nil, err
+		}
+		if items == nil {
+			items = make([]T, 0, p.Page.TotalElements)
+		}
+		items = append(items, pageItems...)
+
+		next, ok := p.Links.Href("next")
+		if !ok {
+			break
+		}
+		u, err = h.relativeURL(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
@@ -0,0 +1,143 @@
+package helpscouttest
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a helpscout.Clock that only moves when Advance or Run is called,
+// so tests can skip over rate limiting, retry backoff, and token expiry
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []clockWaiter
+
+	// added is closed and replaced when After adds a waiter
+	added chan struct{}
+}
+
+type clockWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewClock returns a Clock stopped at the given time
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the clock's current time
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the clock's time
+// once it's been advanced by at least d
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, clockWaiter{at: c.now.Add(d), ch: ch})
+	if c.added != nil {
+		close(c.added)
+		c.added = nil
+	}
+	return ch
+}
+
+// Advance moves the clock forward by d, firing every After channel that's
+// due in order, each with its own time. A goroutine that waits again once
+// it's woken, e.g. for the next retry's backoff, waits from the new time;
+// call BlockUntil before advancing past a wait that may not have started
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].at.Before(c.waiters[j].at)
+	})
+	due := sort.Search(len(c.waiters), func(i int) bool {
+		return c.waiters[i].at.After(c.now)
+	})
+	for _, w := range c.waiters[:due] {
+		w.ch <- w.at
+	}
+	c.waiters = append([]clockWaiter(nil), c.waiters[due:]...)
+}
+
+// BlockUntil blocks until at least n After channels are waiting to fire,
+// e.g. until a retry is backing off, so advancing the clock fires them
+func (c *Clock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		if len(c.waiters) >= n {
+			c.mu.Unlock()
+			return
+		}
+		if c.added == nil {
+			c.added = make(chan struct{})
+		}
+		added := c.added
+		c.mu.Unlock()
+
+		<-added
+	}
+}
+
+// Run calls fn, advancing the clock to each After channel in turn until fn
+// returns, so its retry backoff and rate limiting take no time
+func (c *Clock) Run(fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		default:
+		}
+
+		c.mu.Lock()
+		next := time.Duration(-1)
+		for _, w := range c.waiters {
+			if d := w.at.Sub(c.now); next < 0 || d < next {
+				next = d
+			}
+		}
+		if c.added == nil {
+			c.added = make(chan struct{})
+		}
+		added := c.added
+		c.mu.Unlock()
+
+		if next >= 0 {
+			c.Advance(next)
+			continue
+		}
+
+		select {
+		case err := <-done:
+			return err
+		case <-added:
+		}
+	}
+}
+
+// Waiters returns the number of After channels that haven't fired yet.
+// Rate limited requests also wait on the clock, for a minute each
+func (c *Clock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
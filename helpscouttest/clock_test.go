@@ -0,0 +1,70 @@
+package helpscouttest
+
+import (
+	"testing"
+	"time"
+)
+
+var clockStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestAdvanceFiresDueWaiters(t *testing.T) {
+	clk := NewClock(clockStart)
+	later := clk.After(2 * time.Second)
+	sooner := clk.After(time.Second)
+	never := clk.After(time.Minute)
+
+	clk.Advance(5 * time.Second)
+	if got, want := <-sooner, clockStart.Add(time.Second); !got.Equal(want) {
+		t.Errorf("sooner fired at %s, want %s", got, want)
+	}
+	if got, want := <-later, clockStart.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("later fired at %s, want %s", got, want)
+	}
+	select {
+	case <-never:
+		t.Error("a waiter fired before it was due")
+	default:
+	}
+
+	if got, want := clk.Now(), clockStart.Add(5*time.Second); !got.Equal(want) {
+		t.Errorf("Now() = %s, want %s", got, want)
+	}
+}
+
+func TestBlockUntilWaitsForWaiters(t *testing.T) {
+	clk := NewClock(clockStart)
+	woken := make(chan time.Time)
+	go func() {
+		for i := 0; i < 3; i++ {
+			woken <- <-clk.After(time.Second)
+		}
+	}()
+
+	for i := 1; i <= 3; i++ {
+		clk.BlockUntil(1)
+		clk.Advance(time.Second)
+		if got, want := <-woken, clockStart.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+			t.Errorf("wait %d fired at %s, want %s", i, got, want)
+		}
+	}
+	if n := clk.Waiters(); n != 0 {
+		t.Errorf("%d waiters are left", n)
+	}
+}
+
+func TestRunAdvancesUntilDone(t *testing.T) {
+	clk := NewClock(clockStart)
+	err := clk.Run(func() error {
+		for _, d := range []time.Duration{time.Second, time.Minute, time.Hour} {
+			<-clk.After(d)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := clk.Now(), clockStart.Add(time.Hour+time.Minute+time.Second); !got.Equal(want) {
+		t.Errorf("Now() = %s, want %s", got, want)
+	}
+}
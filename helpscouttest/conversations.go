@@ -0,0 +1,146 @@
+package helpscouttest
+
+import (
+	"net/http"
+	"strconv"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+)
+
+// newThreadTypes maps the endpoints that create threads to the types they create
+var newThreadTypes = map[string]helpscout.ThreadType{
+	"customer": helpscout.ThreadTypeCustomer,
+	"reply":    helpscout.ThreadTypeReply,
+	"notes":    helpscout.ThreadTypeNote,
+	"chats":    helpscout.ThreadTypeChat,
+	"phones":   helpscout.ThreadTypePhone,
+}
+
+// routeConversations dispatches requests under /v2/conversations
+func (s *Server) routeConversations(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.serveListConversations(w, r)
+		case http.MethodPost:
+			s.serveCreateConversation(w, r)
+		default:
+			s.error(w, http.StatusMethodNotAllowed, r.Method+" isn't allowed on conversations")
+		}
+		return
+	}
+
+	c := s.conversation(atoi(path[0]))
+	if c == nil {
+		s.error(w, http.StatusNotFound, "no such conversation: "+path[0])
+		return
+	}
+
+	switch {
+	case len(path) == 1 && r.Method == http.MethodGet:
+		if r.URL.Query().Get("embed") == "threads" {
+			s.json(w, http.StatusOK, s.withThreads(c))
+			return
+		}
+		s.json(w, http.StatusOK, c)
+	case len(path) == 1 && r.Method == http.MethodPatch:
+		var op helpscout.PatchOp
+		if !s.decode(w, r, &op) {
+			return
+		}
+		s.noContent(w, s.patchConversation(c, op))
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		s.deleteConversation(c.ID)
+		s.noContent(w, nil)
+	case len(path) == 2 && path[1] == "threads" && r.Method == http.MethodGet:
+		page(s, w, r, "threads", s.renderThreads(c.ID))
+	case len(path) == 2 && path[1] == "fields" && r.Method == http.MethodPut:
+		var rq helpscout.RqUpdateCustomFields
+		if !s.decode(w, r, &rq) {
+			return
+		}
+		s.noContent(w, s.updateCustomFields(c, rq.Fields))
+	case len(path) == 2 && r.Method == http.MethodPost && newThreadTypes[path[1]] != "":
+		var nt helpscout.NewThread
+		if !s.decode(w, r, &nt) {
+			return
+		}
+		nt.Type = newThreadTypes[path[1]]
+		if err := nt.Validate(); err != nil {
+			s.error(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		t := s.addThread(c, nt)
+		s.created(w, "conversations/"+path[0]+"/threads", t.ID, nil)
+	case len(path) >= 3 && path[1] == "threads":
+		t := s.thread(c.ID, atoi(path[2]))
+		if t == nil {
+			s.error(w, http.StatusNotFound, "no such thread: "+path[2])
+			return
+		}
+		switch {
+		case len(path) == 3 && r.Method == http.MethodPatch:
+			var op helpscout.PatchOp
+			if !s.decode(w, r, &op) {
+				return
+			}
+			s.noContent(w, s.patchThread(t, op))
+		case len(path) == 3 && r.Method == http.MethodDelete:
+			s.deleteThread(c, t.ID)
+			s.noContent(w, nil)
+		case len(path) == 4 && path[3] == "attachments" && r.Method == http.MethodPost:
+			s.serveUploadAttachment(w, r, c.ID, t.ID)
+		default:
+			s.error(w, http.StatusNotFound, "no such endpoint: "+r.Method+" "+r.URL.Path)
+		}
+	default:
+		s.error(w, http.StatusNotFound, "no such endpoint: "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func (s *Server) serveCreateConversation(w http.ResponseWriter, r *http.Request) {
+	var rq helpscout.CreateConversationRequest
+	if !s.decode(w, r, &rq) {
+		return
+	}
+
+	c, err := s.createConversation(rq)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if r.URL.Query().Get("reload") == "true" {
+		s.created(w, "conversations", c.ID, s.withThreads(c))
+		return
+	}
+	s.created(w, "conversations", c.ID, nil)
+}
+
+func (s *Server) serveListConversations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	status := q.Get("status")
+	if len(status) == 0 {
+		status = string(helpscout.ConversationStatusActive)
+	}
+
+	page(s, w, r, "conversations", s.listConversations(atoi(q.Get("mailbox")), status, q.Get("query")))
+}
+
+func (s *Server) serveUploadAttachment(w http.ResponseWriter, r *http.Request, conversationID int, threadID int) {
+	var rq struct {
+		FileName string `json:"fileName"`
+		MimeType string `json:"mimeType"`
+		Data     []byte `json:"data"`
+	}
+	if !s.decode(w, r, &rq) {
+		return
+	}
+
+	a, err := s.uploadAttachment(conversationID, threadID, rq.FileName, rq.MimeType, rq.Data)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.created(w, "conversations/"+strconv.Itoa(conversationID)+"/threads/"+strconv.Itoa(threadID)+"/attachments", a.ID, nil)
+}
@@ -0,0 +1,251 @@
+package helpscouttest
+
+import (
+	"fmt"
+	"time"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+)
+
+// Fake is an in-memory helpscout.Client for unit tests that don't need HTTP.
+// It shares Server's account model, so IDs, ordering, validation, and custom
+// field checks behave the same. It's safe for concurrent use
+type Fake struct {
+	*store
+
+	mailboxID       int
+	mailboxSelected bool
+}
+
+var _ helpscout.Client = (*Fake)(nil)
+
+// NewFake returns an empty fake Help Scout account
+func NewFake() *Fake {
+	return &Fake{store: newStore("https://api.helpscout.net/v2/", time.Now)}
+}
+
+// CreateConversation creates a conversation and returns its ID
+func (f *Fake) CreateConversation(rq helpscout.CreateConversationRequest) (conversationID int, resp []byte, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if rq.MailboxID == 0 {
+		rq.MailboxID = f.mailboxID
+	}
+	c, err := f.createConversation(rq)
+	if err != nil {
+		return
+	}
+	return c.ID, nil, nil
+}
+
+// CreateAndReloadConversation creates a conversation and returns it, including its threads
+func (f *Fake) CreateAndReloadConversation(rq helpscout.CreateConversationRequest) (conversation helpscout.Conversation, resp []byte, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if rq.MailboxID == 0 {
+		rq.MailboxID = f.mailboxID
+	}
+	c, err := f.createConversation(rq)
+	if err != nil {
+		return
+	}
+	return f.withThreads(c), nil, nil
+}
+
+// ListConversations returns every conversation in the current mailbox
+// matching the query, newest first
+func (f *Fake) ListConversations(query string) (conversations []helpscout.Conversation, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.listConversations(f.mailboxID, "all", query), nil
+}
+
+// ListConversationsByEmail returns all conversations for the given email
+func (f *Fake) ListConversationsByEmail(email string) (conversations []helpscout.Conversation, err error) {
+	return f.ListConversations(`(email:"` + email + `")`)
+}
+
+// CreateThread adds the given thread to an existing conversation
+// and returns the new thread's ID
+func (f *Fake) CreateThread(conversationID int, thread helpscout.NewThread) (threadID int, err error) {
+	err = thread.Validate()
+	if err != nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := f.conversation(conversationID)
+	if c == nil {
+		return 0, fmt.Errorf("conversation %d doesn't exist", conversationID)
+	}
+	return f.addThread(c, thread).ID, nil
+}
+
+// GetThreads returns the conversation's threads, newest first
+func (f *Fake) GetThreads(conversationID int) (threads []helpscout.Thread, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conversation(conversationID) == nil {
+		return nil, fmt.Errorf("conversation %d doesn't exist", conversationID)
+	}
+	return f.renderThreads(conversationID), nil
+}
+
+// UpdateThreadText replaces the text of the given thread
+func (f *Fake) UpdateThreadText(conversationID int, thread helpscout.Thread, text string) (err error) {
+	if !thread.Type.TextEditable() {
+		return &helpscout.ThreadNotEditableError{ThreadID: thread.ID, Type: thread.Type, Edit: "text"}
+	}
+	if len(text) == 0 {
+		return fmt.Errorf("thread text cannot be blank")
+	}
+
+	return f.patchThread(conversationID, thread.ID, "/text", text)
+}
+
+// HideThread hides the given thread in the conversation
+func (f *Fake) HideThread(conversationID int, thread helpscout.Thread) (err error) {
+	return f.setThreadHidden(conversationID, thread, true)
+}
+
+// UnhideThread shows the given previously hidden thread in the conversation
+func (f *Fake) UnhideThread(conversationID int, thread helpscout.Thread) (err error) {
+	return f.setThreadHidden(conversationID, thread, false)
+}
+
+func (f *Fake) setThreadHidden(conversationID int, thread helpscout.Thread, hidden bool) (err error) {
+	if !thread.Type.Hideable() {
+		return &helpscout.ThreadNotEditableError{ThreadID: thread.ID, Type: thread.Type, Edit: "visibility"}
+	}
+
+	return f.patchThread(conversationID, thread.ID, "/hidden", hidden)
+}
+
+func (f *Fake) patchThread(conversationID int, threadID int, path string, value interface{}) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	t := f.thread(conversationID, threadID)
+	if t == nil {
+		return fmt.Errorf("thread %d of conversation %d doesn't exist", threadID, conversationID)
+	}
+	return f.store.patchThread(t, helpscout.PatchOp{Op: helpscout.PatchOpReplace, Path: path, Value: value})
+}
+
+// ListCustomFields returns all the current mailbox's custom fields
+func (f *Fake) ListCustomFields() (fields helpscout.RsListMailboxCustomFields, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	items := append([]helpscout.CustomField{}, f.fields[f.mailboxID]...)
+	fields.Embedded.Fields = items
+	fields.Page = helpscout.PageInfo{
+		Size:          len(items),
+		TotalElements: len(items),
+		TotalPages:    1,
+		Number:        1,
+	}
+	return
+}
+
+// GetCustomFieldIDByName gets a custom field ID by name in the current mailbox
+func (f *Fake) GetCustomFieldIDByName(name string) (customerFieldID int, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.customFieldID(name)
+}
+
+func (f *Fake) customFieldID(name string) (customerFieldID int, err error) {
+	for _, field := range f.fields[f.mailboxID] {
+		if field.Name == name {
+			return field.ID, nil
+		}
+	}
+	return 0, fmt.Errorf("couldn't find the custom field %q", name)
+}
+
+// UpdateCustomFields updates all custom fields' values for the given conversation
+func (f *Fake) UpdateCustomFields(conversationID int, fields map[string]interface{}) (err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	values := make([]helpscout.RqUpdateCustomField, 0, len(fields))
+	for name, v := range fields {
+		id, err := f.customFieldID(name)
+		if err != nil {
+			return err
+		}
+		values = append(values, helpscout.RqUpdateCustomField{ID: id, Value: v})
+	}
+
+	c := f.conversation(conversationID)
+	if c == nil {
+		return fmt.Errorf("conversation %d doesn't exist", conversationID)
+	}
+	return f.updateCustomFields(c, values)
+}
+
+// SetMailboxID sets the current mailbox ID
+func (f *Fake) SetMailboxID(id int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.mailboxID = id
+	f.mailboxSelected = true
+}
+
+// DeselectMailbox set no currently selected mailbox
+func (f *Fake) DeselectMailbox() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.mailboxID = 0
+	f.mailboxSelected = false
+}
+
+// SelectMailbox selects the mailbox with the given ID, name, or email address
+func (f *Fake) SelectMailbox(mailbox interface{}) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.mailboxID = 0
+	f.mailboxSelected = false
+	for _, m := range f.mailboxes {
+		switch mailbox.(type) {
+		case string:
+			if m.Email == mailbox || m.Name == mailbox {
+				f.mailboxID, f.mailboxSelected = m.ID, true
+			}
+		case int:
+			if m.ID == mailbox {
+				f.mailboxID, f.mailboxSelected = m.ID, true
+			}
+		default:
+			return fmt.Errorf("%Ts aren't supported for selecting a mailbox", mailbox)
+		}
+		if f.mailboxSelected {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Couldn't find mailbox named/with id '%v'", mailbox)
+}
+
+// UploadAttachment uploads an attachment to the given conversation > thread
+func (f *Fake) UploadAttachment(conversationID int, threadID int, name string, mimeType string, data []byte) (resp []byte, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.thread(conversationID, threadID) == nil {
+		return nil, fmt.Errorf("thread %d of conversation %d doesn't exist", threadID, conversationID)
+	}
+	_, err = f.uploadAttachment(conversationID, threadID, name, mimeType, data)
+	return
+}
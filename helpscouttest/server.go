@@ -0,0 +1,325 @@
+// Package helpscouttest provides an in-memory Help Scout Mailbox API,
+// served by an httptest.Server, for exercising helpscout clients offline
+package helpscouttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+)
+
+// DefaultRateLimit is the number of requests per minute a new Server allows,
+// the same as Help Scout's standard plan
+const DefaultRateLimit = 400
+
+// DefaultTokenLifetime is how long a new Server's access tokens last, the same as Help Scout's
+const DefaultTokenLifetime = 48 * time.Hour
+
+// DefaultPageSize is the number of items per page of a new Server's list responses
+const DefaultPageSize = 25
+
+// Server is an in-memory Help Scout. It's safe for concurrent use
+type Server struct {
+	*httptest.Server
+	*store
+
+	AppID     string
+	AppSecret string
+
+	// RateLimit is the number of requests allowed per minute
+	// before the server starts responding with 429s
+	RateLimit int
+
+	// PageSize is the number of items per page of list responses
+	PageSize int
+
+	// TokenLifetime is how long access tokens last
+	TokenLifetime time.Duration
+
+	// Clock times the rate limit's minutes and token expiry.
+	// Share a Clock with the client to skip ahead in both
+	Clock helpscout.Clock
+
+	tokens    map[string]time.Time
+	tokenNum  int
+	failures  []int
+	requests  []string
+	rateStart time.Time
+	rateUsed  int
+}
+
+// NewServer starts an empty Help Scout that accepts the returned
+// server's AppID and AppSecret. Close it when done
+func NewServer() *Server {
+	s := &Server{
+		AppID:         "helpscouttest-app-id",
+		AppSecret:     "helpscouttest-app-secret",
+		RateLimit:     DefaultRateLimit,
+		PageSize:      DefaultPageSize,
+		TokenLifetime: DefaultTokenLifetime,
+		Clock:         helpscout.SystemClock,
+		tokens:        make(map[string]time.Time),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.store = newStore(s.BaseURL(), func() time.Time { return s.Clock.Now() })
+	return s
+}
+
+// BaseURL returns the URL clients should send their requests to
+func (s *Server) BaseURL() string {
+	return s.URL + "/v2/"
+}
+
+// NewClient returns a helpscout client connected to the server, sharing its Clock
+func (s *Server) NewClient() (*helpscout.HelpScout, error) {
+	h, err := helpscout.NewWithBaseURL(s.AppID, s.AppSecret, s.BaseURL())
+	if err != nil {
+		return nil, err
+	}
+	h.SetClock(s.Clock)
+	return h, nil
+}
+
+// Fail makes the next n API requests fail with the given status code, e.g.
+// 429 or 500. A 401 also revokes every access token, like an expired token
+// would. Token requests are never failed, so clients can always recover
+func (s *Server) Fail(statusCode int, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < n; i++ {
+		s.failures = append(s.failures, statusCode)
+	}
+}
+
+// RevokeTokens invalidates every access token handed out so far
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens = make(map[string]time.Time)
+}
+
+// Requests returns every request the server has received,
+// in order, as "METHOD /path?query"
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	if path == r.URL.Path {
+		s.error(w, http.StatusNotFound, "only the v2 API is served")
+		return
+	}
+
+	if path == "oauth2/token" {
+		s.token(w, r)
+		return
+	}
+
+	if !s.rateLimit(w) {
+		return
+	}
+
+	if len(s.failures) != 0 {
+		statusCode := s.failures[0]
+		s.failures = s.failures[1:]
+		if statusCode == http.StatusUnauthorized {
+			s.tokens = make(map[string]time.Time)
+		}
+		if statusCode == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+		s.error(w, statusCode, "injected failure")
+		return
+	}
+
+	expiry, ok := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok || !s.Clock.Now().Before(expiry) {
+		s.error(w, http.StatusUnauthorized, "invalid or expired access token")
+		return
+	}
+
+	s.route(w, r, strings.Split(strings.Trim(path, "/"), "/"))
+}
+
+// rateLimit sets the rate limit headers and responds with a 429 if the
+// minute's requests are used up. It reports whether the request may continue
+func (s *Server) rateLimit(w http.ResponseWriter) bool {
+	now := s.Clock.Now()
+	if now.Sub(s.rateStart) >= time.Minute {
+		s.rateStart = now
+		s.rateUsed = 0
+	}
+	s.rateUsed++
+
+	remaining := s.RateLimit - s.rateUsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	w.Header().Set("X-Ratelimit-Limit-Minute", strconv.Itoa(s.RateLimit))
+	w.Header().Set("X-Ratelimit-Remaining-Minute", strconv.Itoa(remaining))
+
+	if s.rateUsed > s.RateLimit {
+		w.Header().Set("X-Ratelimit-Retry-After", strconv.Itoa(int((time.Minute-now.Sub(s.rateStart)).Seconds())+1))
+		s.error(w, http.StatusTooManyRequests, "rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		s.error(w, http.StatusMethodNotAllowed, "tokens are requested with a POST")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		s.error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if r.PostForm.Get("grant_type") != "client_credentials" ||
+		r.PostForm.Get("client_id") != s.AppID ||
+		r.PostForm.Get("client_secret") != s.AppSecret {
+		s.error(w, http.StatusUnauthorized, "invalid client credentials")
+		return
+	}
+
+	s.tokenNum++
+	token := fmt.Sprintf("helpscouttest-token-%d", s.tokenNum)
+	s.tokens[token] = s.Clock.Now().Add(s.TokenLifetime)
+	s.json(w, http.StatusOK, map[string]interface{}{
+		"token_type":   "bearer",
+		"access_token": token,
+		"expires_in":   int(s.TokenLifetime / time.Second),
+	})
+}
+
+func (s *Server) json(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) error(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/vnd.error+json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"logRef":  strconv.Itoa(s.nextID),
+		"message": message,
+	})
+}
+
+// created responds with a 201 and the new resource's ID, like Help Scout's
+// create endpoints. If v isn't nil it's sent as the body, as with ?reload=true
+func (s *Server) created(w http.ResponseWriter, path string, resourceID int, v interface{}) {
+	w.Header().Set("Resource-ID", strconv.Itoa(resourceID))
+	w.Header().Set("Location", s.BaseURL()+path+"/"+strconv.Itoa(resourceID))
+	if v != nil {
+		s.json(w, http.StatusCreated, v)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// noContent responds with a 204, or with a 400 if err isn't nil
+func (s *Server) noContent(w http.ResponseWriter, err error) {
+	if err != nil {
+		s.error(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// page responds with the given page of items, embedded under key,
+// with links to the neighbouring pages
+func page[T any](s *Server, w http.ResponseWriter, r *http.Request, key string, items []T) {
+	number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if number < 1 {
+		number = 1
+	}
+	totalPages := (len(items) + s.PageSize - 1) / s.PageSize
+
+	start := (number - 1) * s.PageSize
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + s.PageSize
+	if end > len(items) {
+		end = len(items)
+	}
+
+	link := func(n int) helpscout.Link {
+		q := r.URL.Query()
+		q.Set("page", strconv.Itoa(n))
+		return helpscout.Link{Href: s.BaseURL() + strings.TrimPrefix(r.URL.Path, "/v2/") + "?" + q.Encode()}
+	}
+	links := helpscout.Links{
+		"self":  link(number),
+		"first": link(1),
+	}
+	if totalPages > 0 {
+		links["last"] = link(totalPages)
+	}
+	if number > 1 {
+		links["prev"] = link(number - 1)
+	}
+	if number < totalPages {
+		links["next"] = link(number + 1)
+	}
+
+	s.json(w, http.StatusOK, helpscout.Page[T]{
+		Embedded: map[string][]T{key: append([]T{}, items[start:end]...)},
+		Links:    links,
+		Page: helpscout.PageInfo{
+			Size:          s.PageSize,
+			TotalElements: len(items),
+			TotalPages:    totalPages,
+			Number:        number,
+		},
+	})
+}
+
+// decode reads the request's JSON body into v, responding with a 400 on failure
+func (s *Server) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		s.error(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// atoi parses a path segment as a resource ID
+func atoi(s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
+// route dispatches an authenticated request by its path segments
+func (s *Server) route(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "mailboxes" && r.Method == http.MethodGet:
+		page(s, w, r, "mailboxes", s.mailboxes)
+	case len(path) == 3 && path[0] == "mailboxes" && path[2] == "fields" && r.Method == http.MethodGet:
+		page(s, w, r, "fields", s.fields[atoi(path[1])])
+	case len(path) >= 1 && path[0] == "conversations":
+		s.routeConversations(w, r, path[1:])
+	default:
+		s.error(w, http.StatusNotFound, "no such endpoint: "+r.Method+" "+r.URL.Path)
+	}
+}
@@ -0,0 +1,179 @@
+package helpscouttest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+)
+
+const (
+	listRequest  = "GET /v2/conversations?status=all&mailbox=1&page=1"
+	tokenRequest = "POST /v2/oauth2/token"
+)
+
+// newTestServer returns a server on a stopped clock, and a client
+// sharing the clock with the server's first mailbox selected
+func newTestServer(t *testing.T) (s *Server, h *helpscout.HelpScout, clk *Clock) {
+	t.Helper()
+
+	s = NewServer()
+	t.Cleanup(s.Close)
+	clk = NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.Clock = clk
+
+	h, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.SetMailboxID(s.AddMailbox("Support", "support@example.com"))
+	return
+}
+
+// requestsSince returns the requests the server received after the first n
+func requestsSince(s *Server, n int) []string {
+	return s.Requests()[n:]
+}
+
+func listConversations(h *helpscout.HelpScout) error {
+	_, err := h.ListConversations("")
+	return err
+}
+
+func TestRevokedTokenIsRefreshed(t *testing.T) {
+	s, h, clk := newTestServer(t)
+	before := len(s.Requests())
+	oldToken := h.ReadAccessToken()
+
+	s.RevokeTokens()
+	err := clk.Run(func() error { return listConversations(h) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{listRequest, tokenRequest, listRequest}
+	if got := requestsSince(s, before); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+	if h.ReadAccessToken() == oldToken {
+		t.Error("access token wasn't replaced")
+	}
+}
+
+func TestExpiredTokenIsRefreshed(t *testing.T) {
+	s, h, clk := newTestServer(t)
+	before := len(s.Requests())
+
+	clk.Advance(s.TokenLifetime)
+	err := clk.Run(func() error { return listConversations(h) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The client knows the token expired, so it gets a new one before asking
+	want := []string{tokenRequest, listRequest}
+	if got := requestsSince(s, before); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidCredentials(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	h, err := helpscout.NewWithBaseURL(s.AppID, "wrong", s.BaseURL())
+	if err == nil {
+		t.Fatal("expected an error for the wrong app secret")
+	}
+	if h != nil {
+		t.Error("got a connection for the wrong app secret")
+	}
+
+	if got, want := s.Requests(), []string{tokenRequest}; !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
+
+func TestFailuresAreRetried(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		n          int
+		want       []string
+	}{
+		{401, 1, []string{listRequest, tokenRequest, listRequest}},
+		{429, 2, []string{listRequest, listRequest, listRequest}},
+		{500, 2, []string{listRequest, listRequest, listRequest}},
+	}
+
+	for _, tt := range tests {
+		s, h, clk := newTestServer(t)
+		before := len(s.Requests())
+
+		s.Fail(tt.statusCode, tt.n)
+		err := clk.Run(func() error { return listConversations(h) })
+		if err != nil {
+			t.Errorf("%d: %s", tt.statusCode, err)
+			continue
+		}
+
+		if got := requestsSince(s, before); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%d: requests = %q, want %q", tt.statusCode, got, tt.want)
+		}
+	}
+}
+
+func TestClientErrorsAreNotRetried(t *testing.T) {
+	for _, statusCode := range []int{400, 403, 404, 422} {
+		s, h, _ := newTestServer(t)
+		before := len(s.Requests())
+
+		// The clock isn't advanced, so a retry would wait forever
+		s.Fail(statusCode, 1)
+		if err := listConversations(h); err == nil {
+			t.Errorf("%d: expected an error", statusCode)
+		}
+
+		if got, want := requestsSince(s, before), []string{listRequest}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: requests = %q, want %q", statusCode, got, want)
+		}
+	}
+}
+
+func TestRateLimitIsPerClock(t *testing.T) {
+	s, h, _ := newTestServer(t)
+	s.RateLimit = 2
+	for i := 0; i < s.RateLimit; i++ {
+		if err := listConversations(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// h's clock is stopped, so its minute never ends, but
+	// connections on other clocks aren't held up by it
+	_, other, _ := newTestServer(t)
+	if err := listConversations(other); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetriesGiveUp(t *testing.T) {
+	s, h, clk := newTestServer(t)
+	before := len(s.Requests())
+
+	s.Fail(500, helpscout.RetryCount)
+	err := clk.Run(func() error { return listConversations(h) })
+	if err == nil {
+		t.Fatal("expected an error after every retry failed")
+	}
+
+	if got := len(requestsSince(s, before)); got != helpscout.RetryCount {
+		t.Errorf("sent %d requests, want %d", got, helpscout.RetryCount)
+	}
+
+	// The failures are used up, so the next call succeeds
+	err = clk.Run(func() error { return listConversations(h) })
+	if err != nil {
+		t.Fatal(err)
+	}
+}
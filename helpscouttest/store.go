@@ -0,0 +1,553 @@
+package helpscouttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+)
+
+// Attachment is a file uploaded to a thread
+type Attachment struct {
+	ID             int
+	ConversationID int
+	ThreadID       int
+	FileName       string
+	MimeType       string
+	Data           []byte
+}
+
+// searchTerm matches the field:"value" terms of a conversation search query
+var searchTerm = regexp.MustCompile(`(\w+):"([^"]*)"`)
+
+// store is the in-memory Help Scout account behind both Server and Fake.
+// Its unexported methods expect mu to be held
+type store struct {
+	mu            sync.Mutex
+	baseURL       string
+	now           func() time.Time // timestamps created and updated resources
+	nextID        int
+	mailboxes     []helpscout.Mailbox
+	fields        map[int][]helpscout.CustomField
+	conversations []*helpscout.Conversation
+	threads       map[int][]*helpscout.Thread
+	attachments   []Attachment
+}
+
+func newStore(baseURL string, now func() time.Time) *store {
+	return &store{
+		baseURL: baseURL,
+		now:     now,
+		nextID:  1,
+		fields:  make(map[int][]helpscout.CustomField),
+		threads: make(map[int][]*helpscout.Thread),
+	}
+}
+
+// AddMailbox adds a mailbox and returns its ID
+func (s *store) AddMailbox(name string, email string) (mailboxID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now().UTC()
+	mailboxID = s.newID()
+	s.mailboxes = append(s.mailboxes, helpscout.Mailbox{
+		ID:        mailboxID,
+		Name:      name,
+		Slug:      strconv.Itoa(mailboxID),
+		Email:     email,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Links: helpscout.Links{
+			"self":   {Href: s.baseURL + "mailboxes/" + strconv.Itoa(mailboxID)},
+			"fields": {Href: s.baseURL + "mailboxes/" + strconv.Itoa(mailboxID) + "/fields"},
+		},
+	})
+	return
+}
+
+// AddCustomField adds a custom field to the given mailbox and returns its ID
+func (s *store) AddCustomField(mailboxID int, field helpscout.CustomField) (fieldID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	field.ID = s.newID()
+	field.Order = len(s.fields[mailboxID]) + 1
+	s.fields[mailboxID] = append(s.fields[mailboxID], field)
+	return field.ID
+}
+
+// Conversation returns a copy of the conversation with
+// the given ID, with its threads embedded
+func (s *store) Conversation(conversationID int) (conversation helpscout.Conversation, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.conversation(conversationID)
+	if c == nil {
+		return conversation, false
+	}
+	return s.withThreads(c), true
+}
+
+// Attachments returns every attachment uploaded to the given thread
+func (s *store) Attachments(conversationID int, threadID int) (attachments []Attachment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, a := range s.attachments {
+		if a.ConversationID == conversationID && a.ThreadID == threadID {
+			attachments = append(attachments, a)
+		}
+	}
+	return
+}
+
+// newID returns the next unused resource ID
+func (s *store) newID() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+// mailbox returns the mailbox with the given ID, or nil
+func (s *store) mailbox(mailboxID int) *helpscout.Mailbox {
+	for i := range s.mailboxes {
+		if s.mailboxes[i].ID == mailboxID {
+			return &s.mailboxes[i]
+		}
+	}
+	return nil
+}
+
+// conversation returns the conversation with the given ID, or nil
+func (s *store) conversation(conversationID int) *helpscout.Conversation {
+	for _, c := range s.conversations {
+		if c.ID == conversationID {
+			return c
+		}
+	}
+	return nil
+}
+
+// thread returns the given thread of the given conversation, or nil
+func (s *store) thread(conversationID int, threadID int) *helpscout.Thread {
+	for _, t := range s.threads[conversationID] {
+		if t.ID == threadID {
+			return t
+		}
+	}
+	return nil
+}
+
+func (s *store) createConversation(rq helpscout.CreateConversationRequest) (*helpscout.Conversation, error) {
+	rq.SetDefaults()
+	err := rq.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if len(rq.Customer.Email) == 0 && rq.Customer.ID == 0 {
+		return nil, fmt.Errorf("customer needs an email or ID")
+	}
+	if s.mailbox(rq.MailboxID) == nil {
+		return nil, fmt.Errorf("mailbox %d doesn't exist", rq.MailboxID)
+	}
+
+	now := s.now().UTC()
+	c := &helpscout.Conversation{
+		ID:            s.newID(),
+		Type:          rq.Type,
+		Status:        rq.Status,
+		State:         "published",
+		Subject:       rq.Subject,
+		MailboxID:     rq.MailboxID,
+		CreatedAt:     now,
+		UserUpdatedAt: now,
+	}
+	c.Number = c.ID
+	if rq.Created != nil {
+		c.CreatedAt = time.Time(*rq.Created)
+	}
+	if rq.Closed != nil {
+		c.ClosedAt = time.Time(*rq.Closed)
+	}
+	for _, tag := range rq.Tags {
+		c.Tags = append(c.Tags, map[string]interface{}{"tag": tag})
+	}
+	if len(rq.Fields) != 0 {
+		err = s.updateCustomFields(c, rq.Fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.PrimaryCustomer.ID = rq.Customer.ID
+	if c.PrimaryCustomer.ID == 0 {
+		c.PrimaryCustomer.ID = s.newID()
+	}
+	c.PrimaryCustomer.Type = "customer"
+	c.PrimaryCustomer.Email = rq.Customer.Email
+	c.PrimaryCustomer.First = rq.Customer.FirstName
+	c.PrimaryCustomer.Last = rq.Customer.LastName
+	if rq.User != 0 {
+		c.CreatedBy.ID = rq.User
+		c.CreatedBy.Type = "user"
+	} else {
+		c.CreatedBy.ID = c.PrimaryCustomer.ID
+		c.CreatedBy.Type = "customer"
+		c.CreatedBy.Email = c.PrimaryCustomer.Email
+	}
+	c.Links.Self.Href = s.baseURL + "conversations/" + strconv.Itoa(c.ID)
+	c.Links.Threads.Href = c.Links.Self.Href + "/threads"
+	c.Links.Mailbox.Href = s.baseURL + "mailboxes/" + strconv.Itoa(c.MailboxID)
+
+	s.conversations = append(s.conversations, c)
+	for _, nt := range rq.Threads {
+		if nt.Customer.ID == 0 && len(nt.Customer.Email) == 0 {
+			nt.Customer = rq.Customer
+		}
+		s.addThread(c, nt)
+	}
+
+	return c, nil
+}
+
+// addThread adds a thread made from nt to the given conversation
+func (s *store) addThread(c *helpscout.Conversation, nt helpscout.NewThread) *helpscout.Thread {
+	t := &helpscout.Thread{
+		ID:        s.newID(),
+		Type:      nt.Type,
+		Status:    string(c.Status),
+		State:     helpscout.ThreadStatePublished,
+		Body:      nt.Content,
+		Cc:        nt.Cc,
+		Bcc:       nt.Bcc,
+		CreatedAt: time.Time(nt.Created),
+	}
+	if nt.Draft {
+		t.State = helpscout.ThreadStateDraft
+	}
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now().UTC()
+	}
+
+	t.Customer.ID = nt.Customer.ID
+	t.Customer.Email = nt.Customer.Email
+	t.Customer.First = nt.Customer.FirstName
+	t.Customer.Last = nt.Customer.LastName
+	if t.Customer.ID == 0 && t.Customer.Email == c.PrimaryCustomer.Email {
+		t.Customer.ID = c.PrimaryCustomer.ID
+	}
+	if nt.User != 0 {
+		t.CreatedBy.ID = nt.User
+		t.CreatedBy.Type = "user"
+	} else {
+		t.CreatedBy.ID = t.Customer.ID
+		t.CreatedBy.Type = "customer"
+		t.CreatedBy.Email = t.Customer.Email
+	}
+	if t.Type == helpscout.ThreadTypeReply && len(t.Customer.Email) != 0 {
+		t.To = []string{t.Customer.Email}
+	}
+
+	// Help Scout lists threads newest first
+	s.threads[c.ID] = append([]*helpscout.Thread{t}, s.threads[c.ID]...)
+	c.Threads = len(s.threads[c.ID])
+	c.Preview = t.Body
+	if len(c.Preview) > 255 {
+		c.Preview = c.Preview[:255]
+	}
+	c.UserUpdatedAt = t.CreatedAt
+	return t
+}
+
+// renderThreads returns copies of the given conversation's
+// threads with their attachments embedded
+func (s *store) renderThreads(conversationID int) []helpscout.Thread {
+	threads := make([]helpscout.Thread, 0, len(s.threads[conversationID]))
+	for _, t := range s.threads[conversationID] {
+		thread := *t
+
+		type attachment struct {
+			ID       int    `json:"id"`
+			Filename string `json:"filename"`
+			MimeType string `json:"mimeType"`
+			Size     int    `json:"size"`
+		}
+		var attachments []attachment
+		for _, a := range s.attachments {
+			if a.ThreadID == t.ID {
+				attachments = append(attachments, attachment{
+					ID:       a.ID,
+					Filename: a.FileName,
+					MimeType: a.MimeType,
+					Size:     len(a.Data),
+				})
+			}
+		}
+		if len(attachments) != 0 {
+			b, _ := json.Marshal(attachments)
+			json.Unmarshal(b, &thread.Embedded.Attachments)
+		}
+
+		threads = append(threads, thread)
+	}
+	return threads
+}
+
+// withThreads returns a copy of the given conversation with its threads embedded
+func (s *store) withThreads(c *helpscout.Conversation) helpscout.Conversation {
+	conversation := *c
+	conversation.Embedded.Threads = s.renderThreads(c.ID)
+	return conversation
+}
+
+// listConversations returns the conversations matching a list request,
+// newest first. A mailboxID of 0 matches every mailbox, and a status of
+// "all" every status
+func (s *store) listConversations(mailboxID int, status string, search string) []helpscout.Conversation {
+	conversations := make([]helpscout.Conversation, 0, len(s.conversations))
+	for _, c := range s.conversations {
+		if mailboxID > 0 && c.MailboxID != mailboxID {
+			continue
+		}
+		if status != "all" && string(c.Status) != status {
+			continue
+		}
+		if len(search) != 0 && !s.matches(c, search) {
+			continue
+		}
+		conversations = append(conversations, *c)
+	}
+
+	sort.SliceStable(conversations, func(i, j int) bool {
+		return conversations[i].CreatedAt.After(conversations[j].CreatedAt)
+	})
+	return conversations
+}
+
+// matches reports whether the conversation matches the search query. Only
+// field:"value" terms for email, subject, and body are understood; anything
+// else is searched for in the subject and threads
+func (s *store) matches(c *helpscout.Conversation, search string) bool {
+	terms := searchTerm.FindAllStringSubmatch(search, -1)
+	if len(terms) == 0 {
+		text := strings.Trim(search, `()" `)
+		terms = [][]string{{"", "subject", text}, {"", "body", text}}
+	}
+
+	for _, term := range terms {
+		value := strings.ToLower(term[2])
+		switch term[1] {
+		case "email":
+			if strings.ToLower(c.PrimaryCustomer.Email) == value {
+				return true
+			}
+			for _, t := range s.threads[c.ID] {
+				if strings.ToLower(t.Customer.Email) == value {
+					return true
+				}
+			}
+		case "subject":
+			if strings.Contains(strings.ToLower(c.Subject), value) {
+				return true
+			}
+		case "body":
+			for _, t := range s.threads[c.ID] {
+				if strings.Contains(strings.ToLower(t.Body), value) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s *store) patchConversation(c *helpscout.Conversation, op helpscout.PatchOp) error {
+	if op.Op != helpscout.PatchOpReplace {
+		return fmt.Errorf("unsupported operation: %s", op.Op)
+	}
+
+	switch v := op.Value.(type) {
+	case string:
+		switch op.Path {
+		case "/subject":
+			c.Subject = v
+		case "/status":
+			c.Status = helpscout.ConversationStatus(v)
+			if c.Status == helpscout.ConversationStatusClosed {
+				c.ClosedAt = s.now().UTC()
+			}
+		default:
+			return fmt.Errorf("unsupported path: %s", op.Path)
+		}
+	case float64:
+		if op.Path != "/primaryCustomer.id" {
+			return fmt.Errorf("unsupported path: %s", op.Path)
+		}
+		c.PrimaryCustomer.ID = int(v)
+	default:
+		return fmt.Errorf("unsupported value for %s", op.Path)
+	}
+
+	c.UserUpdatedAt = s.now().UTC()
+	return nil
+}
+
+// deleteConversation removes the conversation and everything in it
+func (s *store) deleteConversation(conversationID int) {
+	for i, c := range s.conversations {
+		if c.ID == conversationID {
+			s.conversations = append(s.conversations[:i], s.conversations[i+1:]...)
+			break
+		}
+	}
+	delete(s.threads, conversationID)
+
+	attachments := s.attachments[:0]
+	for _, a := range s.attachments {
+		if a.ConversationID != conversationID {
+			attachments = append(attachments, a)
+		}
+	}
+	s.attachments = attachments
+}
+
+func (s *store) patchThread(t *helpscout.Thread, op helpscout.PatchOp) error {
+	if op.Op != helpscout.PatchOpReplace {
+		return fmt.Errorf("unsupported operation: %s", op.Op)
+	}
+
+	switch v := op.Value.(type) {
+	case string:
+		if op.Path != "/text" {
+			return fmt.Errorf("unsupported path: %s", op.Path)
+		}
+		if !t.Type.TextEditable() {
+			return fmt.Errorf("%s threads can't be edited", t.Type)
+		}
+		t.Body = v
+	case bool:
+		switch {
+		case op.Path == "/hidden" && !t.Type.Hideable():
+			return fmt.Errorf("%s threads can't be hidden", t.Type)
+		case op.Path == "/hidden" && v:
+			t.State = helpscout.ThreadStateHidden
+		case op.Path == "/hidden" && !v, op.Path == "/draft" && !v:
+			t.State = helpscout.ThreadStatePublished
+		case op.Path == "/draft" && v:
+			t.State = helpscout.ThreadStateDraft
+		default:
+			return fmt.Errorf("unsupported path: %s", op.Path)
+		}
+	default:
+		return fmt.Errorf("unsupported value for %s", op.Path)
+	}
+	return nil
+}
+
+// deleteThread removes the given thread from the conversation
+func (s *store) deleteThread(c *helpscout.Conversation, threadID int) {
+	threads := s.threads[c.ID]
+	for i, t := range threads {
+		if t.ID == threadID {
+			s.threads[c.ID] = append(threads[:i], threads[i+1:]...)
+			break
+		}
+	}
+	c.Threads = len(s.threads[c.ID])
+}
+
+// updateCustomFields replaces the conversation's custom field values,
+// checking that each field exists in its mailbox and that number and
+// dropdown values fit their field
+func (s *store) updateCustomFields(c *helpscout.Conversation, values []helpscout.RqUpdateCustomField) error {
+	fields := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		var field *helpscout.CustomField
+		for i := range s.fields[c.MailboxID] {
+			if s.fields[c.MailboxID][i].ID == v.ID {
+				field = &s.fields[c.MailboxID][i]
+			}
+		}
+		if field == nil {
+			return fmt.Errorf("custom field %d doesn't exist in mailbox %d", v.ID, c.MailboxID)
+		}
+
+		err := validateCustomFieldValue(*field, v.Value)
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, map[string]interface{}{
+			"id":    v.ID,
+			"name":  field.Name,
+			"value": v.Value,
+		})
+	}
+
+	c.CustomFields = fields
+	return nil
+}
+
+// validateCustomFieldValue checks values the way Help Scout does:
+// numbers must be numeric and dropdowns must be given an option ID
+func validateCustomFieldValue(field helpscout.CustomField, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	var n float64
+	var numeric bool
+	switch v := value.(type) {
+	case int:
+		n, numeric = float64(v), true
+	case int64:
+		n, numeric = float64(v), true
+	case float64:
+		n, numeric = v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		n, numeric = f, err == nil
+	}
+
+	switch strings.ToLower(field.Type) {
+	case "number":
+		if !numeric {
+			return fmt.Errorf("custom field %q needs a number, got %v", field.Name, value)
+		}
+	case "dropdown":
+		if numeric {
+			for _, o := range field.Options {
+				if float64(o.ID) == n {
+					return nil
+				}
+			}
+		}
+		return fmt.Errorf("%v isn't an option ID of custom field %q", value, field.Name)
+	}
+	return nil
+}
+
+func (s *store) uploadAttachment(conversationID int, threadID int, name string, mimeType string, data []byte) (Attachment, error) {
+	if len(name) == 0 || len(mimeType) == 0 || len(data) == 0 {
+		return Attachment{}, fmt.Errorf("attachments need a file name, MIME type, and data")
+	}
+
+	a := Attachment{
+		ID:             s.newID(),
+		ConversationID: conversationID,
+		ThreadID:       threadID,
+		FileName:       name,
+		MimeType:       mimeType,
+		Data:           data,
+	}
+	s.attachments = append(s.attachments, a)
+	return a, nil
+}
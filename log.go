@@ -0,0 +1,153 @@
+package helpscout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	. "github.com/logrusorgru/aurora"
+)
+
+// LevelTrace is the level request and response bodies are logged at,
+// below slog.LevelDebug since they're big and may hold customer data
+const LevelTrace = slog.LevelDebug - 4
+
+// verboseHandler is where logs go for connections without a Logger when Verbose is set
+var verboseHandler = NewColorHandler(os.Stdout, &slog.HandlerOptions{Level: LevelTrace})
+
+// logHandler returns the handler the connection logs to, wrapped so that
+// everything logged is redacted, or nil if it doesn't log
+func (h *HelpScout) logHandler() slog.Handler {
+	if h.Logger != nil {
+		return h.redactingHandler(h.Logger.Handler())
+	}
+	if Verbose {
+		return h.redactingHandler(verboseHandler)
+	}
+	return nil
+}
+
+// logEnabled reports whether anything would be logged at the given level
+func (h *HelpScout) logEnabled(ctx context.Context, level slog.Level) bool {
+	handler := h.logHandler()
+	return handler != nil && handler.Enabled(ctx, level)
+}
+
+// logAttrs logs msg with the connection number and the given attributes,
+// timed by the connection's clock
+func (h *HelpScout) logAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
+	handler := h.logHandler()
+	if handler == nil || !handler.Enabled(ctx, level) {
+		return
+	}
+
+	r := slog.NewRecord(h.clock().Now(), level, msg, 0)
+	r.AddAttrs(slog.Int("conn", h.ConnNum))
+	r.AddAttrs(attrs...)
+	handler.Handle(ctx, r)
+}
+
+// ColorHandler is a slog.Handler that writes colored lines for reading in a
+// terminal, the way this package logged before it used slog, e.g.
+//
+//	2006-01-02 15:04:05.000000 HelpScout0: DEBUG request method=GET path=mailboxes
+type ColorHandler struct {
+	w     io.Writer
+	mtx   *sync.Mutex
+	level slog.Leveler
+	attrs []slog.Attr // keys already include the groups they were added in
+	group string      // prefix for the keys of later attributes, e.g. "request."
+}
+
+// NewColorHandler returns a ColorHandler writing to w. Only opts' Level is used
+func NewColorHandler(w io.Writer, opts *slog.HandlerOptions) *ColorHandler {
+	c := &ColorHandler{
+		w:     w,
+		mtx:   &sync.Mutex{},
+		level: slog.LevelInfo,
+	}
+	if opts != nil && opts.Level != nil {
+		c.level = opts.Level
+	}
+	return c
+}
+
+// Enabled reports whether records at the given level are written
+func (c *ColorHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= c.level.Level()
+}
+
+// Handle writes the record as a single line
+func (c *ColorHandler) Handle(_ context.Context, r slog.Record) error {
+	var attrs []slog.Attr
+	conn := "HelpScout"
+	add := func(a slog.Attr) {
+		if a.Key == "conn" {
+			conn = fmt.Sprintf("HelpScout%v", a.Value)
+			return
+		}
+		attrs = append(attrs, a)
+	}
+	for _, a := range c.attrs {
+		add(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		a.Key = c.group + a.Key
+		add(a)
+		return true
+	})
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s: %s %s", r.Time.Format("2006-01-02 15:04:05.000000"), Colorize(conn, MagentaFg|BoldFm), colorLevel(r.Level), r.Message)
+	for _, a := range attrs {
+		v := a.Value.Resolve().String()
+		if strings.ContainsAny(v, " \t\n\"=") {
+			v = fmt.Sprintf("%q", v)
+		}
+		fmt.Fprintf(&b, " %s=%s", Bold(a.Key), v)
+	}
+	b.WriteByte('\n')
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, err := c.w.Write(b.Bytes())
+	return err
+}
+
+// WithAttrs returns a handler that adds the given attributes to every record
+func (c *ColorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h := *c
+	h.attrs = append([]slog.Attr{}, c.attrs...)
+	for _, a := range attrs {
+		a.Key = c.group + a.Key
+		h.attrs = append(h.attrs, a)
+	}
+	return &h
+}
+
+// WithGroup returns a handler that prefixes the keys of later attributes with name
+func (c *ColorHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return c
+	}
+	h := *c
+	h.group = c.group + name + "."
+	return &h
+}
+
+func colorLevel(level slog.Level) Value {
+	switch {
+	case level >= slog.LevelError:
+		return Red(level)
+	case level >= slog.LevelWarn:
+		return Yellow(level)
+	case level >= slog.LevelInfo:
+		return Cyan(level)
+	}
+	return Gray(12, level)
+}
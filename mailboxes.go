@@ -5,49 +5,15 @@ import (
 	"time"
 )
 
-type respMailboxes struct {
-	Embedded struct {
-		Mailboxes []struct {
-			ID        int       `json:"id"`
-			Name      string    `json:"name"`
-			Slug      string    `json:"slug"`
-			Email     string    `json:"email"`
-			CreatedAt time.Time `json:"createdAt"`
-			UpdatedAt time.Time `json:"updatedAt"`
-			Links     struct {
-				Fields struct {
-					Href string `json:"href"`
-				} `json:"fields"`
-				Folders struct {
-					Href string `json:"href"`
-				} `json:"folders"`
-				Self struct {
-					Href string `json:"href"`
-				} `json:"self"`
-			} `json:"_links"`
-		} `json:"mailboxes"`
-	} `json:"_embedded"`
-	Links struct {
-		First struct {
-			Href string `json:"href"`
-		} `json:"first"`
-		Last struct {
-			Href string `json:"href"`
-		} `json:"last"`
-		Page struct {
-			Href      string `json:"href"`
-			Templated bool   `json:"templated"`
-		} `json:"page"`
-		Self struct {
-			Href string `json:"href"`
-		} `json:"self"`
-	} `json:"_links"`
-	Page struct {
-		Size          int `json:"size"`
-		TotalElements int `json:"totalElements"`
-		TotalPages    int `json:"totalPages"`
-		Number        int `json:"number"`
-	} `json:"page"`
+// Mailbox is a Help Scout mailbox
+type Mailbox struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Links     Links     `json:"_links"`
 }
 
 // SetMailboxID sets the current mailbox ID
@@ -65,15 +31,14 @@ func (h *HelpScout) DeselectMailbox() {
 // SelectMailbox searches for a mailbox ID with the given ID,
 // mailbox name, or email address and selects it
 func (h *HelpScout) SelectMailbox(mailbox interface{}) error {
-	r, _, _, err := h.Exec("mailboxes", nil, &respMailboxes{}, "")
+	mailboxes, err := ListAll[Mailbox](h, "mailboxes")
 	if err != nil {
 		return err
 	}
-	resp := r.(*respMailboxes)
 
 	h.DeselectMailbox()
 L:
-	for _, m := range resp.Embedded.Mailboxes {
+	for _, m := range mailboxes {
 		switch mailbox.(type) {
 		case string:
 			if m.Email == mailbox || m.Name == mailbox {
@@ -2,35 +2,43 @@ package helpscout
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
-
-	retry "github.com/StirlingMarketingGroup/go-retry"
-
-	. "github.com/logrusorgru/aurora"
 )
 
 // RetryCount is the number of times retired functions get retried
 var RetryCount = 10
 
-func log(connNum int, msg interface{}) {
-	fmt.Println(Sprintf("%s %s: %s", time.Now().Format("2006-01-02 15:04:05.000000"), Colorize(fmt.Sprintf("HelpScout%d", connNum), MagentaFg|BoldFm), msg))
-}
+// RetryBackoff is how long a failed request waits before being retried,
+// doubling with every retry after that, up to RetryMaxBackoff
+var RetryBackoff = 500 * time.Millisecond
+
+// RetryMaxBackoff is the longest a failed request waits before being retried
+var RetryMaxBackoff = 30 * time.Second
 
 // Verbose outputs every command and its response with the Help Scout API
+// to stdout for connections without a Logger.
+//
+// Deprecated: set the connection's Logger instead
 var Verbose = false
 
-// ShowPostData being set to false will hide the query in requests in verbose mode
+// ShowPostData being set to false will hide the query in requests in verbose mode.
+//
+// Deprecated: request bodies are logged at LevelTrace; set the Logger's level above it
 var ShowPostData = true
 
-// ShowResponse being set to false will hide any Help Scout responses in verbose mode
+// ShowResponse being set to false will hide any Help Scout responses in verbose mode.
+//
+// Deprecated: response bodies are logged at LevelTrace; set the Logger's level above it
 var ShowResponse = true
 
 // RateLimitPercent is the percent (as a decimal) of how much of the available rate limit to use. E.g., rate limit is 400/minute; if .75 is given, then 300/minute will be this instance's effective rate limit
@@ -38,7 +46,20 @@ var RateLimitPercent float64 = 1
 
 // CurrentRateMinute is the current count of API requests in the last minute
 // var currentRateMinute = 0
-var currentRateMinuteCh chan struct{} // = make(chan struct{}, RateLimitMinute)
+
+// rateMinute holds a slot for every API request in the last minute
+type rateMinute struct {
+	current chan struct{} // = make(chan struct{}, RateLimitMinute)
+
+	// docs is current for the Docs API, which is rate limited separately
+	docs chan struct{}
+}
+
+// rateMinutes are the rate limits of the connections on each clock. Every
+// connection on the real clock shares one, but a test's stopped clock only
+// holds up the connections on it, since its slots are freed as it advances
+var rateMinutes = make(map[Clock]*rateMinute)
+var rateMinutesMtx = sync.Mutex{}
 
 // var currentRateMinuteMtx = sync.RWMutex{}
 
@@ -52,6 +73,34 @@ type HelpScout struct {
 	ConnNum         int
 	accessTokenMtx  sync.RWMutex
 	reqMtx          sync.Mutex
+
+	// docsAPIKey is set for connections to the Docs API,
+	// which uses basic auth instead of OAuth
+	docsAPIKey string
+
+	// BaseURL overrides the URL every request is relative to, e.g. to point
+	// the connection at a helpscouttest server. It must end in a slash
+	BaseURL string
+
+	// Transport sends the connection's HTTP requests, e.g. a cassette.Recorder.
+	// http.DefaultTransport is used if it's nil
+	Transport http.RoundTripper
+
+	// Logger receives the connection's logs: requests and responses at
+	// slog.LevelDebug, their bodies at LevelTrace, retries at slog.LevelWarn,
+	// and requests that failed for good at slog.LevelError. If it's nil,
+	// nothing is logged unless Verbose is set
+	Logger *slog.Logger
+
+	// Redactor masks personal data in the connection's logs. Credentials and
+	// email addresses are always masked; if it's nil, so are DefaultRedactFields
+	Redactor *Redactor
+
+	// clk is set by SetClock
+	clk Clock
+
+	// accessTokenExpiry is when AccessToken stops working
+	accessTokenExpiry time.Time
 }
 
 // ReadAccessToken safely returns the access token in a async-safe way
@@ -65,29 +114,49 @@ func (h *HelpScout) ReadAccessToken() string {
 var nextConnNum = 0
 var nextConnNumMutex = sync.RWMutex{}
 
-// New returns a new Help Scout instance
-func New(appID string, appSecret string) (h *HelpScout, err error) {
-	nextConnNumMutex.RLock()
-	connNum := nextConnNum
-	nextConnNumMutex.RUnlock()
-
+func getNextConnNum() int {
 	nextConnNumMutex.Lock()
+	connNum := nextConnNum
 	nextConnNum++
 	nextConnNumMutex.Unlock()
+	return connNum
+}
 
-	h = &HelpScout{
-		AppID:       appID,
-		AppSecret:   appSecret,
-		AccessToken: "",
-		ConnNum:     connNum,
-	}
+// New returns a new Help Scout instance
+func New(appID string, appSecret string) (h *HelpScout, err error) {
+	return NewWithBaseURL(appID, appSecret, "")
+}
 
-	err = h.GetNewAccessToken()
+// NewWithBaseURL returns a new Help Scout instance that sends its requests
+// to the given base URL instead of Help Scout's
+func NewWithBaseURL(appID string, appSecret string, baseURL string) (h *HelpScout, err error) {
+	return newHelpScout(&HelpScout{
+		AppID:     appID,
+		AppSecret: appSecret,
+		BaseURL:   baseURL,
+	})
+}
+
+// NewWithTransport returns a new Help Scout instance that sends its requests,
+// including the one for its first access token, through the given transport
+func NewWithTransport(appID string, appSecret string, transport http.RoundTripper) (h *HelpScout, err error) {
+	return newHelpScout(&HelpScout{
+		AppID:     appID,
+		AppSecret: appSecret,
+		Transport: transport,
+	})
+}
+
+// newHelpScout numbers the given connection and gets its first access token
+func newHelpScout(h *HelpScout) (*HelpScout, error) {
+	h.ConnNum = getNextConnNum()
+
+	err := h.GetNewAccessToken()
 	if err != nil {
 		return nil, err
 	}
 
-	return
+	return h, nil
 }
 
 type respToken struct {
@@ -101,6 +170,7 @@ func (h *HelpScout) GetNewAccessToken() (err error) {
 	accessToken := h.ReadAccessToken()
 
 	h.accessTokenMtx.Lock()
+	defer h.accessTokenMtx.Unlock()
 	if accessToken == h.AccessToken {
 		r, _, _, _, err := h.RawExec("oauth2/token", url.Values{
 			"client_id":     {h.AppID},
@@ -113,12 +183,83 @@ func (h *HelpScout) GetNewAccessToken() (err error) {
 
 		resp := r.(*respToken)
 		h.AccessToken = resp.AccessToken
+		h.accessTokenExpiry = time.Time{}
+		if resp.ExpiresIn > 0 {
+			h.accessTokenExpiry = h.clock().Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+		}
 	}
-	h.accessTokenMtx.Unlock()
 
 	return
 }
 
+// accessTokenExpired reports whether the access token is past its expiry
+func (h *HelpScout) accessTokenExpired() bool {
+	h.accessTokenMtx.RLock()
+	expiry := h.accessTokenExpiry
+	h.accessTokenMtx.RUnlock()
+	return !expiry.IsZero() && !h.clock().Now().Before(expiry)
+}
+
+// retryNow is returned by an attempt that should be retried without waiting,
+// e.g. after getting a new access token
+type retryNow struct {
+	err error
+}
+
+func (e *retryNow) Error() string {
+	return e.err.Error()
+}
+
+// statusCodeError is returned for responses that aren't 2xx
+type statusCodeError struct {
+	statusCode int
+}
+
+func (e *statusCodeError) Error() string {
+	return fmt.Sprintf("received status code %d", e.statusCode)
+}
+
+// retryable reports whether the same request could succeed later. Only
+// timeouts, rate limiting, and server errors could
+func (e *statusCodeError) retryable() bool {
+	return e.statusCode >= 500 ||
+		e.statusCode == http.StatusRequestTimeout || e.statusCode == http.StatusTooManyRequests
+}
+
+// retry calls fn until it succeeds, fails RetryCount times, fails in a way
+// retrying won't fix, or ctx is done, waiting on the connection's clock
+// between attempts. Errors other than a response's status code, e.g.
+// network errors, are always retried
+func (h *HelpScout) retry(ctx context.Context, fn func(attempt int) error) (err error) {
+	backoff := RetryBackoff
+	for attempt := 1; ; attempt++ {
+		err = fn(attempt)
+		if err == nil || attempt >= RetryCount {
+			return
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if e, ok := err.(*statusCodeError); ok && !e.retryable() {
+			return
+		}
+		h.logAttrs(ctx, slog.LevelWarn, "retrying", slog.Int("attempt", attempt), slog.String("error", err.Error()))
+		if _, ok := err.(*retryNow); ok {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-h.clock().After(backoff):
+		}
+		backoff *= 2
+		if backoff > RetryMaxBackoff {
+			backoff = RetryMaxBackoff
+		}
+	}
+}
+
 // func getCurrentRateMinute() int {
 // 	currentRateMinuteMtx.RLock()
 // 	i := currentRateMinute
@@ -126,37 +267,79 @@ func (h *HelpScout) GetNewAccessToken() (err error) {
 // 	return i
 // }
 
+// rateMinuteCh returns the rate limit channel of the connection's API on the given clock
+func (h *HelpScout) rateMinuteCh(clk Clock) *chan struct{} {
+	rateMinutesMtx.Lock()
+	defer rateMinutesMtx.Unlock()
+
+	m, ok := rateMinutes[clk]
+	if !ok {
+		m = &rateMinute{}
+		rateMinutes[clk] = m
+	}
+	if len(h.docsAPIKey) != 0 {
+		return &m.docs
+	}
+	return &m.current
+}
+
+// baseURL returns the URL every request of this connection is relative to
+func (h *HelpScout) baseURL() string {
+	if len(h.BaseURL) != 0 {
+		return h.BaseURL
+	}
+	if len(h.docsAPIKey) != 0 {
+		return "https://docsapi.helpscout.net/v1/"
+	}
+	return "https://api.helpscout.net/v2/"
+}
+
 // RawExec sends a request to the given URL with the given params to the
-// Help Scout API and returns its response
+// Help Scout API and returns its response. If the request fails, the status,
+// headers, and body of the last response received are still returned
 func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method string, rateLimited bool, mutexLocked bool) (r interface{}, statusCode int, header http.Header, resp []byte, err error) {
-	u = "https://api.helpscout.net/v2/" + u
+	return h.rawExec(context.Background(), u, v, dest, method, rateLimited, mutexLocked)
+}
+
+// rawExec is RawExec, but stops retrying once the given context is done
+func (h *HelpScout) rawExec(ctx context.Context, u string, v interface{}, dest interface{}, method string, rateLimited bool, mutexLocked bool) (r interface{}, statusCode int, header http.Header, resp []byte, err error) {
+	path := u
+	u = h.baseURL() + u
+	clk := h.clock()
+	rateMinuteCh := h.rateMinuteCh(clk)
 	client := &http.Client{
-		Timeout: time.Minute,
+		Timeout:   time.Minute,
+		Transport: h.Transport,
 	}
 
 	var body []byte
-	var _req *http.Request
-	var _params string
+	var attempts int
 	var _resp *http.Response
-	err = retry.Retry(func() (err error) {
+	logBodies := h.logEnabled(ctx, LevelTrace)
+	err = h.retry(ctx, func(attempt int) (err error) {
+		attempts = attempt
+		err = ctx.Err()
+		if err != nil {
+			return
+		}
+
 		var req *http.Request
-		_req = req
 		var params string
 		if v == nil {
 			if len(method) == 0 {
 				method = "GET"
 			}
-			req, err = http.NewRequest(method, u, nil)
+			req, err = http.NewRequestWithContext(ctx, method, u, nil)
 		} else {
 			if len(method) == 0 {
 				method = "POST"
 			}
 			switch v.(type) {
 			case url.Values:
-				if Verbose {
+				if logBodies {
 					params = v.(url.Values).Encode()
 				}
-				req, err = http.NewRequest(method, u, strings.NewReader(v.(url.Values).Encode()))
+				req, err = http.NewRequestWithContext(ctx, method, u, strings.NewReader(v.(url.Values).Encode()))
 				req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 			default:
 				var j []byte
@@ -164,10 +347,10 @@ func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method st
 				if err != nil {
 					return
 				}
-				if Verbose {
+				if logBodies {
 					params = string(j)
 				}
-				req, err = http.NewRequest(method, u, bytes.NewBuffer(j))
+				req, err = http.NewRequestWithContext(ctx, method, u, bytes.NewBuffer(j))
 				req.Header.Add("Content-Type", "application/json")
 			}
 		}
@@ -175,26 +358,32 @@ func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method st
 			return
 		}
 
-		var accessToken string
-		if mutexLocked {
-			accessToken = h.AccessToken
+		if len(h.docsAPIKey) != 0 {
+			req.SetBasicAuth(h.docsAPIKey, "X")
 		} else {
-			accessToken = h.ReadAccessToken()
-		}
-		if len(accessToken) != 0 {
-			req.Header.Add("Authorization", "Bearer "+accessToken)
+			var accessToken string
+			if mutexLocked {
+				accessToken = h.AccessToken
+			} else {
+				accessToken = h.ReadAccessToken()
+			}
+			if len(accessToken) != 0 {
+				req.Header.Add("Authorization", "Bearer "+accessToken)
+			}
 		}
 
-		if Verbose {
-			var q string
-			_params = params
-			if ShowPostData {
-				q = params
-			}
-			log(h.ConnNum, strings.Replace(fmt.Sprintf("%s %03d/%03d %s %s %s", Bold("->"), len(currentRateMinuteCh), cap(currentRateMinuteCh), req.Method, u, q), fmt.Sprintf(`"%s"`, h.AppSecret), `"****"`, -1))
+		h.logAttrs(ctx, slog.LevelDebug, "request",
+			slog.String("method", req.Method),
+			slog.String("path", path),
+			slog.Int("attempt", attempt),
+			slog.Int("rate_used", len(*rateMinuteCh)),
+			slog.Int("rate_cap", cap(*rateMinuteCh)),
+		)
+		if len(params) != 0 && (h.Logger != nil || ShowPostData) {
+			h.logAttrs(ctx, LevelTrace, "request body", slog.String("method", req.Method), slog.String("path", path), slog.String("body", params))
 		}
 
-		if rateLimited && currentRateMinuteCh != nil {
+		if rateLimited && *rateMinuteCh != nil {
 			payloadRequests := 1
 			switch strings.ToLower(req.Method) {
 			case "post", "put", "delete", "patch":
@@ -202,37 +391,37 @@ func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method st
 			}
 
 			for i := 0; i < payloadRequests; i++ {
-				currentRateMinuteCh <- struct{}{}
+				*rateMinuteCh <- struct{}{}
 				go func() {
-					time.Sleep(time.Minute)
-					<-currentRateMinuteCh
+					<-clk.After(time.Minute)
+					<-*rateMinuteCh
 				}()
 			}
 		}
 
+		start := clk.Now()
 		resp, err := client.Do(req)
 		_resp = resp
 		if err != nil {
 			return fmt.Errorf("helpscout rawexec: %s", err)
 		}
+		duration := clk.Now().Sub(start)
 		// defer resp.Body.Close()
-		if currentRateMinuteCh == nil {
+		if *rateMinuteCh == nil {
 			if rate, ok := resp.Header["X-Ratelimit-Limit-Minute"]; ok {
 				n, _ := strconv.Atoi(rate[0])
-				currentRateMinuteCh = make(chan struct{}, int(float64(n)*RateLimitPercent))
+				*rateMinuteCh = make(chan struct{}, int(float64(n)*RateLimitPercent))
 
-				if Verbose {
-					log(h.ConnNum, fmt.Sprintf("Current rate limit is %d", n))
-				}
+				h.logAttrs(ctx, slog.LevelInfo, "rate limit", slog.Int("limit", n))
 
 				if cur, ok := resp.Header["X-Ratelimit-Remaining-Minute"]; ok {
 					r, _ := strconv.Atoi(cur[0])
 					used := n - r
 					for i := 0; i < used; i++ {
-						currentRateMinuteCh <- struct{}{}
+						*rateMinuteCh <- struct{}{}
 						go func() {
-							time.Sleep(time.Minute)
-							<-currentRateMinuteCh
+							<-clk.After(time.Minute)
+							<-*rateMinuteCh
 						}()
 					}
 				}
@@ -244,49 +433,52 @@ func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method st
 			return
 		}
 
-		if Verbose && ShowResponse {
-			log(h.ConnNum, fmt.Sprintf("<- %s", string(body)))
-		}
 		statusCode = resp.StatusCode
+		remaining := -1
+		if cur := resp.Header.Get("X-Ratelimit-Remaining-Minute"); len(cur) != 0 {
+			remaining, _ = strconv.Atoi(cur)
+		}
+		h.logAttrs(ctx, slog.LevelDebug, "response",
+			slog.String("method", req.Method),
+			slog.String("path", path),
+			slog.Int("status", statusCode),
+			slog.Duration("duration", duration),
+			slog.Int("attempt", attempt),
+			slog.Int("rate_limit_remaining", remaining),
+		)
+		if len(body) != 0 && (h.Logger != nil || ShowResponse) {
+			h.logAttrs(ctx, LevelTrace, "response body", slog.String("method", req.Method), slog.String("path", path), slog.String("body", string(body)))
+		}
 
-		if statusCode == 401 {
+		// A 401 for the token request itself means the credentials are wrong,
+		// and it's sent with the token mutex held, so it isn't refreshed
+		if statusCode == 401 && len(h.docsAPIKey) == 0 && !mutexLocked {
 			err = h.GetNewAccessToken()
 			if err != nil {
 				return
 			}
-			err = &retry.NoFail{Err: fmt.Errorf("received new access token")}
+			err = &retryNow{err: fmt.Errorf("received new access token")}
 			return
 		}
 
+		header = resp.Header
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return fmt.Errorf("received status code %d", resp.StatusCode)
+			return &statusCodeError{statusCode: resp.StatusCode}
 		}
 
-		header = resp.Header
-
 		return
-	}, RetryCount, func(err error) error {
-		if Verbose {
-			log(h.ConnNum, Red(err))
-		}
-		return nil
-	}, nil)
+	})
 	if err != nil {
 		err = fmt.Errorf("helpscout exec: %s", err)
-		if Verbose {
-			fmt.Println("==================\nREQUEST:")
-			if _req != nil {
-				fmt.Println(_req.Header)
-			}
-			fmt.Println(_params)
-			fmt.Println("\nRESPONSE:")
-			if _req != nil {
-				fmt.Println(_resp.Header)
-			}
-			fmt.Printf("%s\n", body)
-			fmt.Println("==================")
-		}
-		return
+		h.logAttrs(ctx, slog.LevelError, "request failed",
+			slog.String("method", method),
+			slog.String("path", path),
+			slog.Int("status", statusCode),
+			slog.Int("attempts", attempts),
+			slog.String("error", err.Error()),
+			slog.String("body", string(body)),
+		)
+		return nil, statusCode, header, body, err
 	}
 
 	if dest != nil {
@@ -296,28 +488,26 @@ func (h *HelpScout) RawExec(u string, v interface{}, dest interface{}, method st
 		}
 	}
 
-	resp, err = ioutil.ReadAll(_resp.Body)
-	if err != nil {
-		return
-	}
 	_resp.Body.Close()
-	return dest, statusCode, header, resp, nil
+	return dest, statusCode, header, body, nil
 }
 
 // Exec wraps the RaWExec function for common requests
 func (h *HelpScout) Exec(u string, v interface{}, dest interface{}, method string) (r interface{}, header http.Header, resp []byte, err error) {
-	if len(h.ReadAccessToken()) == 0 {
+	r, _, header, resp, err = h.exec(context.Background(), u, v, dest, method)
+	return
+}
+
+// exec is Exec, but with a context and the response's status code
+func (h *HelpScout) exec(ctx context.Context, u string, v interface{}, dest interface{}, method string) (r interface{}, statusCode int, header http.Header, resp []byte, err error) {
+	if len(h.docsAPIKey) == 0 && (len(h.ReadAccessToken()) == 0 || h.accessTokenExpired()) {
 		err = h.GetNewAccessToken()
 		if err != nil {
 			return
 		}
 	}
 
-	r, _, header, resp, err = h.RawExec(u, v, dest, method, true, false)
-	if err != nil {
-		return nil, nil, resp, err
-	}
-	return
+	return h.rawExec(ctx, u, v, dest, method, true, false)
 }
 
 // EmailAddresses is a map of email addresses in the form of
@@ -0,0 +1,178 @@
+package helpscout
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// OrganizationPropertyValue is the value of an organization property
+// on a single organization
+type OrganizationPropertyValue struct {
+	ID    int         `json:"id,omitempty"`
+	Slug  string      `json:"slug,omitempty"`
+	Name  string      `json:"name,omitempty"`
+	Type  string      `json:"type,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// Organization is a company that customers can belong to
+// https://developer.helpscout.com/mailbox-api/endpoints/organizations/get/
+type Organization struct {
+	ID                int                         `json:"id,omitempty"`
+	Name              string                      `json:"name"`
+	Website           string                      `json:"website,omitempty"`
+	Description       string                      `json:"description,omitempty"`
+	Note              string                      `json:"note,omitempty"`
+	Location          string                      `json:"location,omitempty"`
+	LogoURL           string                      `json:"logoUrl,omitempty"`
+	BrandColor        string                      `json:"brandColor,omitempty"`
+	Domains           []string                    `json:"domains,omitempty"`
+	Phones            []string                    `json:"phones,omitempty"`
+	Properties        []OrganizationPropertyValue `json:"properties,omitempty"`
+	CustomerCount     int                         `json:"customerCount,omitempty"`
+	ConversationCount int                         `json:"conversationCount,omitempty"`
+	CreatedAt         *time.Time                  `json:"createdAt,omitempty"`
+	UpdatedAt         *time.Time                  `json:"updatedAt,omitempty"`
+}
+
+// RsListOrganizations is a list organizations response
+// https://developer.helpscout.com/mailbox-api/endpoints/organizations/list/
+type RsListOrganizations = Page[Organization]
+
+// ListOrganizations returns every organization in the account
+func (h *HelpScout) ListOrganizations() (organizations []Organization, err error) {
+	return ListAll[Organization](h, "organizations")
+}
+
+// GetOrganization returns the organization with the given ID
+func (h *HelpScout) GetOrganization(organizationID int) (organization Organization, err error) {
+	_, _, _, err = h.Exec("organizations/"+strconv.Itoa(organizationID), nil, &organization, "")
+	return
+}
+
+// stripReadOnly clears the fields Help Scout computes itself
+func (o Organization) stripReadOnly() Organization {
+	o.ID = 0
+	o.CustomerCount = 0
+	o.ConversationCount = 0
+	o.CreatedAt = nil
+	o.UpdatedAt = nil
+	return o
+}
+
+// NewOrganization creates an organization and returns its ID
+func (h *HelpScout) NewOrganization(organization Organization) (organizationID int, err error) {
+	if len(organization.Name) == 0 {
+		return 0, fmt.Errorf("organization names cannot be blank")
+	}
+
+	_, header, _, err := h.Exec("organizations", organization.stripReadOnly(), nil, "POST")
+	if err != nil {
+		return
+	}
+
+	organizationID, _ = strconv.Atoi(header.Get("Resource-ID"))
+	return
+}
+
+// UpdateOrganization replaces the organization with the given organization's ID
+func (h *HelpScout) UpdateOrganization(organization Organization) (err error) {
+	if organization.ID == 0 {
+		return fmt.Errorf("organization ID cannot be blank")
+	}
+	if len(organization.Name) == 0 {
+		return fmt.Errorf("organization names cannot be blank")
+	}
+
+	_, _, _, err = h.Exec("organizations/"+strconv.Itoa(organization.ID), organization.stripReadOnly(), nil, "PUT")
+	return
+}
+
+// DeleteOrganization deletes the organization with the given ID
+func (h *HelpScout) DeleteOrganization(organizationID int) (err error) {
+	_, _, _, err = h.Exec("organizations/"+strconv.Itoa(organizationID), nil, nil, "DELETE")
+	return
+}
+
+// RsListOrganizationCustomers is a list organization customers response
+type RsListOrganizationCustomers = Page[Customer]
+
+// ListOrganizationCustomers returns every customer linked to the given organization
+func (h *HelpScout) ListOrganizationCustomers(organizationID int) (customers []Customer, err error) {
+	return ListAll[Customer](h, "organizations/"+strconv.Itoa(organizationID)+"/customers")
+}
+
+// ListOrganizationConversations returns every conversation with customers
+// linked to the given organization
+func (h *HelpScout) ListOrganizationConversations(organizationID int) (conversations []Conversation, err error) {
+	return ListAll[Conversation](h, "organizations/"+strconv.Itoa(organizationID)+"/conversations")
+}
+
+// LinkCustomerToOrganization sets the organization the given customer belongs to
+func (h *HelpScout) LinkCustomerToOrganization(customerID int, organizationID int) (err error) {
+	return h.ApplyPatch("customers/"+strconv.Itoa(customerID), NewPatch(PatchCustomer).Replace("/organizationId", organizationID))
+}
+
+// UnlinkCustomerFromOrganization removes the given customer from their organization
+func (h *HelpScout) UnlinkCustomerFromOrganization(customerID int) (err error) {
+	return h.ApplyPatch("customers/"+strconv.Itoa(customerID), NewPatch(PatchCustomer).Remove("/organizationId"))
+}
+
+// OrganizationProperty is the definition of a property organizations can have.
+// Type is one of "text", "number", "url", "date", or "dropdown"
+// https://developer.helpscout.com/mailbox-api/endpoints/organizations/properties/list/
+type OrganizationProperty struct {
+	ID      int    `json:"id,omitempty"`
+	Slug    string `json:"slug"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Options []struct {
+		ID    string `json:"id,omitempty"`
+		Label string `json:"label"`
+	} `json:"options,omitempty"`
+}
+
+// ListOrganizationProperties returns all the account's organization property definitions
+func (h *HelpScout) ListOrganizationProperties() (properties []OrganizationProperty, err error) {
+	return ListAll[OrganizationProperty](h, "organizations/properties")
+}
+
+// NewOrganizationProperty creates an organization property definition
+func (h *HelpScout) NewOrganizationProperty(property OrganizationProperty) (err error) {
+	if len(property.Slug) == 0 || len(property.Name) == 0 {
+		return fmt.Errorf("organization properties need a slug and a name")
+	}
+
+	property.ID = 0
+	_, _, _, err = h.Exec("organizations/properties", property, nil, "POST")
+	return
+}
+
+// UpdateOrganizationProperty replaces the organization property definition with the given property's slug
+func (h *HelpScout) UpdateOrganizationProperty(property OrganizationProperty) (err error) {
+	if len(property.Slug) == 0 {
+		return fmt.Errorf("organization property slug cannot be blank")
+	}
+
+	property.ID = 0
+	_, _, _, err = h.Exec("organizations/properties/"+property.Slug, property, nil, "PUT")
+	return
+}
+
+// DeleteOrganizationProperty deletes the organization property definition with the given slug
+func (h *HelpScout) DeleteOrganizationProperty(slug string) (err error) {
+	_, _, _, err = h.Exec("organizations/properties/"+slug, nil, nil, "DELETE")
+	return
+}
+
+// SetOrganizationProperties sets the given property values, keyed by
+// property slug, on the given organization
+func (h *HelpScout) SetOrganizationProperties(organizationID int, values map[string]interface{}) (err error) {
+	patch := NewPatch(PatchOrganization)
+	for slug, v := range values {
+		patch.Replace("/properties/"+slug, v)
+	}
+
+	return h.ApplyPatch("organizations/"+strconv.Itoa(organizationID), patch)
+}
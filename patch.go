@@ -0,0 +1,198 @@
+package helpscout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PatchOp is a single RFC 6902 style patch operation, as used by
+// Help Scout's PATCH endpoints
+type PatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch operations
+const (
+	PatchOpReplace = "replace"
+	PatchOpAdd     = "add"
+	PatchOpRemove  = "remove"
+)
+
+// PatchResource is a kind of resource Help Scout can patch,
+// which decides the paths a patch may touch
+type PatchResource string
+
+// Patchable resources
+const (
+	PatchConversation       PatchResource = "conversation"
+	PatchThread             PatchResource = "thread"
+	PatchCustomer           PatchResource = "customer"
+	PatchCustomerProperties PatchResource = "customer properties"
+	PatchOrganization       PatchResource = "organization"
+	PatchWorkflow           PatchResource = "workflow"
+)
+
+// patchPaths are the paths each resource can be patched at, and the
+// operations allowed on them. Paths ending in a slash match anything
+// under them, e.g. a property slug
+var patchPaths = map[PatchResource]map[string][]string{
+	// https://developer.helpscout.com/mailbox-api/endpoints/conversations/update/
+	PatchConversation: {
+		"/subject":            {PatchOpReplace},
+		"/primaryCustomer.id": {PatchOpReplace},
+		"/draft":              {PatchOpReplace},
+		"/status":             {PatchOpReplace},
+		"/assignTo":           {PatchOpReplace, PatchOpRemove},
+	},
+	// https://developer.helpscout.com/mailbox-api/endpoints/conversations/threads/update/
+	PatchThread: {
+		"/text":   {PatchOpReplace},
+		"/draft":  {PatchOpReplace},
+		"/hidden": {PatchOpReplace},
+	},
+	// https://developer.helpscout.com/mailbox-api/endpoints/customers/update/
+	PatchCustomer: {
+		"/firstName":      {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/lastName":       {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/jobTitle":       {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/location":       {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/background":     {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/age":            {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/gender":         {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/photoUrl":       {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/photoType":      {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/organization":   {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/organizationId": {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+	},
+	// https://developer.helpscout.com/mailbox-api/endpoints/customers/customer_properties/update/
+	PatchCustomerProperties: {
+		"/": {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+	},
+	// https://developer.helpscout.com/mailbox-api/endpoints/organizations/patch/
+	PatchOrganization: {
+		"/name":        {PatchOpReplace},
+		"/website":     {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/description": {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/note":        {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/location":    {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/logoUrl":     {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/brandColor":  {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/domains":     {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/phones":      {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+		"/properties/": {PatchOpReplace, PatchOpAdd, PatchOpRemove},
+	},
+	// https://developer.helpscout.com/mailbox-api/endpoints/workflows/update-status/
+	PatchWorkflow: {
+		"/status": {PatchOpReplace},
+	},
+}
+
+// singleOpPatchResources only take one operation per request,
+// as a lone object instead of an array
+var singleOpPatchResources = map[PatchResource]bool{
+	PatchConversation: true,
+	PatchThread:       true,
+	PatchWorkflow:     true,
+}
+
+// JSONPatch builds the patch operations for a single resource
+type JSONPatch struct {
+	resource PatchResource
+	ops      []PatchOp
+}
+
+// NewPatch returns an empty patch for the given kind of resource
+func NewPatch(resource PatchResource) *JSONPatch {
+	return &JSONPatch{resource: resource}
+}
+
+// Replace sets the value at path
+func (p *JSONPatch) Replace(path string, value interface{}) *JSONPatch {
+	p.ops = append(p.ops, PatchOp{Op: PatchOpReplace, Path: path, Value: value})
+	return p
+}
+
+// Add sets the value at path, which may not be set yet
+func (p *JSONPatch) Add(path string, value interface{}) *JSONPatch {
+	p.ops = append(p.ops, PatchOp{Op: PatchOpAdd, Path: path, Value: value})
+	return p
+}
+
+// Remove clears the value at path
+func (p *JSONPatch) Remove(path string) *JSONPatch {
+	p.ops = append(p.ops, PatchOp{Op: PatchOpRemove, Path: path})
+	return p
+}
+
+// Ops returns the patch's operations
+func (p *JSONPatch) Ops() []PatchOp {
+	return p.ops
+}
+
+// Validate checks every operation's path and op against
+// the fields Help Scout lets the resource be patched at
+func (p *JSONPatch) Validate() error {
+	paths, ok := patchPaths[p.resource]
+	if !ok {
+		return fmt.Errorf("%q isn't a patchable resource", p.resource)
+	}
+	if len(p.ops) == 0 {
+		return fmt.Errorf("%s patch has no operations", p.resource)
+	}
+
+	for _, op := range p.ops {
+		allowed, ok := paths[op.Path]
+		if !ok {
+			for path, a := range paths {
+				if strings.HasSuffix(path, "/") && strings.HasPrefix(op.Path, path) && len(op.Path) > len(path) {
+					allowed, ok = a, true
+					break
+				}
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%q isn't a patchable %s path", op.Path, p.resource)
+		}
+
+		valid := false
+		for _, a := range allowed {
+			if op.Op == a {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("%q operations aren't allowed on %s path %q", op.Op, p.resource, op.Path)
+		}
+	}
+
+	return nil
+}
+
+// ApplyPatch validates the given patch and sends it to the given URL with
+// Patch. Resources that only take one operation per request get one request
+// per operation
+func (h *HelpScout) ApplyPatch(u string, patch *JSONPatch) (err error) {
+	err = patch.Validate()
+	if err != nil {
+		return
+	}
+
+	ctx := context.Background()
+	if !singleOpPatchResources[patch.resource] {
+		_, _, err = Patch[json.RawMessage](ctx, h, u, patch.ops)
+		return
+	}
+
+	for _, op := range patch.ops {
+		_, _, err = Patch[json.RawMessage](ctx, h, u, op)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,67 @@
+package helpscout
+
+import (
+	"fmt"
+	"time"
+)
+
+// NewPhoneConversation logs a phone call with the given customer, taken by the
+// given user, as an imported phone conversation with the given call notes
+func (h *HelpScout) NewPhoneConversation(subject string, customer Customer, user int, created time.Time, tags []string, notes string, closed bool) (conversation Conversation, resp []byte, err error) {
+	if len(notes) == 0 {
+		return conversation, nil, fmt.Errorf("phone call notes cannot be blank")
+	}
+
+	rq := newImportedConversationRequest(subject, customer, created, tags, []NewThread{{
+		Type:     ThreadTypePhone,
+		Customer: Customer{ID: customer.ID, Email: customer.Email},
+		Content:  notes,
+		Imported: true,
+		Created:  Time(created.UTC()),
+		User:     user,
+	}}, closed, user)
+	rq.Type = ConversationTypePhone
+
+	return h.CreateAndReloadConversation(rq)
+}
+
+// ChatMessage is a single message of a chat transcript.
+// Messages from the customer have no User
+type ChatMessage struct {
+	User    int
+	Text    string
+	Created time.Time
+}
+
+// NewChatConversation logs a chat transcript with the given customer as an
+// imported chat conversation, with a thread for every message
+func (h *HelpScout) NewChatConversation(subject string, customer Customer, user int, tags []string, transcript []ChatMessage, closed bool) (conversation Conversation, resp []byte, err error) {
+	if len(transcript) == 0 {
+		return conversation, nil, fmt.Errorf("chat transcripts cannot be empty")
+	}
+
+	threads := make([]NewThread, len(transcript))
+	for i, m := range transcript {
+		t := NewThread{
+			Type:     ThreadTypeChat,
+			Customer: Customer{ID: customer.ID, Email: customer.Email},
+			Content:  m.Text,
+			Imported: true,
+			Created:  Time(m.Created.UTC()),
+		}
+		if m.User != 0 {
+			t.Type = ThreadTypeReply
+			t.User = m.User
+		}
+		threads[i] = t
+	}
+
+	rq := newImportedConversationRequest(subject, customer, transcript[0].Created, tags, threads, closed, user)
+	rq.Type = ConversationTypeChat
+	if closed {
+		closedTime := Time(transcript[len(transcript)-1].Created.UTC())
+		rq.Closed = &closedTime
+	}
+
+	return h.CreateAndReloadConversation(rq)
+}
@@ -0,0 +1,105 @@
+package helpscout
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Rating is a customer's satisfaction rating of a reply thread.
+// Rating is one of "Great", "Okay", or "Not Good"
+// https://developer.helpscout.com/mailbox-api/endpoints/ratings/get/
+type Rating struct {
+	ID             int       `json:"id"`
+	CustomerID     int       `json:"customerId"`
+	UserID         int       `json:"userId"`
+	ThreadID       int       `json:"threadId"`
+	ConversationID int       `json:"conversationId"`
+	MailboxID      int       `json:"mailboxId"`
+	Rating         string    `json:"rating"`
+	Comments       string    `json:"comments"`
+	CreatedAt      time.Time `json:"createdAt"`
+	ModifiedAt     time.Time `json:"modifiedAt"`
+}
+
+// GetRating returns the rating with the given ID
+func (h *HelpScout) GetRating(ratingID int) (rating Rating, err error) {
+	_, _, _, err = h.Exec("ratings/"+strconv.Itoa(ratingID), nil, &rating, "")
+	return
+}
+
+// GetThreadRating returns the rating left on the given thread
+func (h *HelpScout) GetThreadRating(thread Thread) (rating Rating, err error) {
+	if thread.RatingID == 0 {
+		return rating, fmt.Errorf("thread %d hasn't been rated", thread.ID)
+	}
+	return h.GetRating(thread.RatingID)
+}
+
+// HappinessRating is a single entry of the happiness ratings report
+type HappinessRating struct {
+	ConversationID     int       `json:"id"`
+	Number             int       `json:"number"`
+	Type               string    `json:"type"`
+	ThreadID           int       `json:"threadid"`
+	ThreadCreatedAt    time.Time `json:"threadCreatedAt"`
+	RatingID           int       `json:"ratingId"`
+	RatingCustomerID   int       `json:"ratingCustomerId"`
+	RatingCustomerName string    `json:"ratingCustomerName"`
+	RatingUserID       int       `json:"ratingUserId"`
+	RatingUserName     string    `json:"ratingUserName"`
+	RatingComments     string    `json:"ratingComments"`
+	RatingCreatedAt    time.Time `json:"ratingCreatedAt"`
+}
+
+// RsHappinessRatings is a happiness ratings report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/happiness/reports-happiness-ratings/
+type RsHappinessRatings struct {
+	Results []HappinessRating `json:"results"`
+	Page    int               `json:"page"`
+	Pages   int               `json:"pages"`
+	Count   int               `json:"count"`
+}
+
+// ListHappinessRatings returns every rating left within the report's range.
+// rating filters by "great", "ok", or "not-good"; an empty string returns all
+func (h *HelpScout) ListHappinessRatings(rq RqReport, rating string) (ratings []HappinessRating, err error) {
+	if len(rating) == 0 {
+		rating = "all"
+	}
+
+	var rs RsHappinessRatings
+	page := 1
+	for {
+		rs = RsHappinessRatings{}
+		err = h.report("happiness/ratings", rq, url.Values{
+			"rating": {rating},
+			"page":   {strconv.Itoa(page)},
+		}, &rs)
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			ratings = make([]HappinessRating, 0, rs.Count)
+		}
+		ratings = append(ratings, rs.Results...)
+
+		if page >= rs.Pages {
+			break
+		}
+		page++
+	}
+
+	return
+}
+
+// GroupRatingsByUser groups ratings from ListHappinessRatings
+// by the ID of the user who was rated
+func GroupRatingsByUser(ratings []HappinessRating) map[int][]HappinessRating {
+	m := make(map[int][]HappinessRating)
+	for _, r := range ratings {
+		m[r.RatingUserID] = append(m[r.RatingUserID], r)
+	}
+	return m
+}
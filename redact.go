@@ -0,0 +1,256 @@
+package helpscout
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces masked values in logs
+const Redacted = "REDACTED"
+
+// DefaultRedactFields are the JSON fields whose values are masked in logged
+// bodies by connections without a Redactor: customers' names, phone numbers
+// and email lists, and message content. Credential fields are always masked.
+// It's read once, so set a connection's Redactor to change them
+var DefaultRedactFields = []string{
+	"firstName",
+	"lastName",
+	"first",
+	"last",
+	"phone",
+	"phones",
+	"emails",
+	"text",
+	"body",
+	"preview",
+}
+
+// credentialFields are the JSON fields and form
+// values that are masked whatever the Redactor
+var credentialFields = []string{"access_token", "refresh_token", "client_secret", "password"}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s",]+`)
+	formPattern   = regexp.MustCompile(`((?:` + strings.Join(credentialFields, "|") + `)=)[^&\s"]+`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+(?:@|%40)[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+)
+
+// Redactor masks credentials and personal data before they're logged:
+// bearer tokens, access tokens, client secrets, the connection's secrets,
+// email addresses, and the values of the given JSON fields
+type Redactor struct {
+	// fields are the lowercased JSON fields to mask
+	fields map[string]bool
+
+	// fieldPattern matches the fields' scalar values in bodies that aren't valid JSON
+	fieldPattern *regexp.Regexp
+}
+
+// defaultRedactor is used by connections without a Redactor
+var defaultRedactor = NewRedactor(DefaultRedactFields...)
+
+// NewRedactor returns a Redactor that masks the values of the given JSON
+// fields, matched case insensitively, wherever they appear in a body and
+// whatever their type. Include DefaultRedactFields to add to them rather
+// than replace them
+func NewRedactor(fields ...string) *Redactor {
+	r := &Redactor{fields: make(map[string]bool)}
+	quoted := make([]string, 0, len(fields)+len(credentialFields))
+	for _, f := range append(append([]string{}, fields...), credentialFields...) {
+		if len(f) != 0 {
+			r.fields[strings.ToLower(f)] = true
+			quoted = append(quoted, regexp.QuoteMeta(f))
+		}
+	}
+	r.fieldPattern = regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"|-?[0-9][^,}\]\s]*|true|false)`)
+	return r
+}
+
+// Redact returns s with everything the Redactor masks, and every one of the
+// given secrets, replaced with Redacted. JSON objects and arrays are parsed,
+// so fields are masked even when they hold objects or arrays
+func (r *Redactor) Redact(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if len(secret) != 0 {
+			s = strings.Replace(s, secret, Redacted, -1)
+		}
+	}
+
+	if j, ok := r.redactJSON(s); ok {
+		return j
+	}
+
+	s = r.fieldPattern.ReplaceAllString(s, `${1}"`+Redacted+`"`)
+	return r.redactText(s)
+}
+
+// redactText masks the credentials and email addresses in free text
+func (r *Redactor) redactText(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+	s = formPattern.ReplaceAllString(s, "${1}"+Redacted)
+	return emailPattern.ReplaceAllString(s, Redacted)
+}
+
+// redactJSON masks s if it's a JSON object or array, reporting whether it was
+func (r *Redactor) redactJSON(s string) (redacted string, ok bool) {
+	trimmed := strings.TrimSpace(s)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return "", false
+	}
+
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	var v interface{}
+	if dec.Decode(&v) != nil || dec.More() {
+		return "", false
+	}
+
+	b, err := marshalUnescaped(r.redactValue(v))
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// redactValue masks the listed fields of decoded JSON, and the text of its strings
+func (r *Redactor) redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if r.fields[strings.ToLower(k)] {
+				v[k] = Redacted
+				continue
+			}
+			v[k] = r.redactValue(field)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = r.redactValue(v[i])
+		}
+		return v
+	case string:
+		return r.redactText(v)
+	}
+	return v
+}
+
+// marshalUnescaped marshals v without escaping HTML characters, so logged
+// bodies read the way Help Scout sent them
+func marshalUnescaped(v interface{}) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(v)
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), err
+}
+
+// RedactingHandler is a slog.Handler that redacts every attribute, whatever
+// its kind, before passing records on to another handler. Connections wrap
+// their Logger's handler in one, so no handler ever sees a credential
+type RedactingHandler struct {
+	next     slog.Handler
+	redactor *Redactor
+	secrets  []string
+}
+
+// NewRedactingHandler returns a handler that masks attributes with the given
+// Redactor and secrets before passing them on to next. If r is nil,
+// DefaultRedactFields are masked
+func NewRedactingHandler(next slog.Handler, r *Redactor, secrets ...string) *RedactingHandler {
+	if r == nil {
+		r = defaultRedactor
+	}
+	return &RedactingHandler{
+		next:     next,
+		redactor: r,
+		secrets:  secrets,
+	}
+}
+
+// Enabled reports whether the next handler handles records at the given level
+func (rh *RedactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return rh.next.Enabled(ctx, level)
+}
+
+// Handle redacts the record's message and attributes and passes it on
+func (rh *RedactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, rh.redactor.Redact(r.Message, rh.secrets...), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(rh.redactAttr(a))
+		return true
+	})
+	return rh.next.Handle(ctx, redacted)
+}
+
+// WithAttrs redacts the attributes and passes them on
+func (rh *RedactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = rh.redactAttr(a)
+	}
+	h := *rh
+	h.next = rh.next.WithAttrs(redacted)
+	return &h
+}
+
+// WithGroup passes the group on
+func (rh *RedactingHandler) WithGroup(name string) slog.Handler {
+	h := *rh
+	h.next = rh.next.WithGroup(name)
+	return &h
+}
+
+// redactAttr masks the attribute's value, resolving LogValuers and
+// descending into groups. Values of any other type are logged as
+// their redacted JSON, or their redacted text if they aren't JSON
+func (rh *RedactingHandler) redactAttr(a slog.Attr) slog.Attr {
+	v := a.Value.Resolve()
+	switch v.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, rh.redactor.Redact(v.String(), rh.secrets...))
+	case slog.KindGroup:
+		group := v.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = rh.redactAttr(ga)
+		}
+		return slog.Attr{Key: a.Key, Value: slog.GroupValue(redacted...)}
+	case slog.KindAny:
+		return slog.Any(a.Key, rh.redactAny(v.Any()))
+	}
+	return slog.Attr{Key: a.Key, Value: v}
+}
+
+// redactAny redacts a value of any type, keeping its structure if it marshals to JSON
+func (rh *RedactingHandler) redactAny(v interface{}) interface{} {
+	if err, ok := v.(error); ok {
+		return rh.redactor.Redact(err.Error(), rh.secrets...)
+	}
+
+	b, err := json.Marshal(v)
+	if err == nil {
+		var decoded interface{}
+		j := rh.redactor.Redact(string(b), rh.secrets...)
+		dec := json.NewDecoder(strings.NewReader(j))
+		dec.UseNumber()
+		if dec.Decode(&decoded) == nil {
+			return decoded
+		}
+	}
+	return rh.redactor.Redact(fmt.Sprintf("%+v", v), rh.secrets...)
+}
+
+// redactingHandler wraps handler so the connection's Redactor and secrets
+// mask everything logged to it. The access token isn't one of the secrets,
+// since this can be called while it's being replaced; it's only ever sent in
+// headers, which aren't logged, and received as access_token, which is
+// always masked
+func (h *HelpScout) redactingHandler(handler slog.Handler) slog.Handler {
+	return NewRedactingHandler(handler, h.Redactor, h.AppSecret, h.docsAPIKey)
+}
@@ -0,0 +1,187 @@
+package helpscout_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	helpscout "github.com/StirlingMarketingGroup/go-helpscout"
+	"github.com/StirlingMarketingGroup/go-helpscout/helpscouttest"
+)
+
+const (
+	customerEmail = "jane.doe@example.com"
+	customerFirst = "Janet"
+	customerLast  = "Doemeyer"
+	messageText   = "My order number is 12345"
+)
+
+func TestLogsNeverContainCredentials(t *testing.T) {
+	s := helpscouttest.NewServer()
+	defer s.Close()
+	clk := helpscouttest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	s.Clock = clk
+
+	h, err := s.NewClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	h.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: helpscout.LevelTrace}))
+	h.SetMailboxID(s.AddMailbox("Support", "support@example.com"))
+	tokens := []string{h.ReadAccessToken()}
+
+	// A revoked token logs a token request with the client secret,
+	// and a token response with the new access token
+	s.RevokeTokens()
+	err = clk.Run(func() error {
+		_, _, err := h.CreateConversation(helpscout.CreateConversationRequest{
+			Subject:  "Where's my order?",
+			Customer: helpscout.Customer{Email: customerEmail, FirstName: customerFirst, LastName: customerLast},
+			Threads: []helpscout.NewThread{{
+				Type:     helpscout.ThreadTypeCustomer,
+				Customer: helpscout.Customer{Email: customerEmail},
+				Content:  messageText,
+			}},
+		})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens = append(tokens, h.ReadAccessToken())
+
+	err = clk.Run(func() error {
+		_, err := h.ListConversationsByEmail(customerEmail)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Failing every retry logs the failed request, whose path has the email in it
+	s.Fail(500, helpscout.RetryCount)
+	err = clk.Run(func() error {
+		_, err := h.ListConversationsByEmail(customerEmail)
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected the request to fail")
+	}
+
+	out := buf.String()
+	for _, msg := range []string{"request body", "response body", "retrying", "request failed"} {
+		if !strings.Contains(out, `"msg":"`+msg+`"`) {
+			t.Errorf("nothing was logged as %q", msg)
+		}
+	}
+
+	leaks := append([]string{
+		s.AppSecret,
+		customerEmail,
+		"jane.doe%40example.com",
+		customerFirst,
+		customerLast,
+		messageText,
+	}, tokens...)
+	for _, leak := range leaks {
+		if strings.Contains(out, leak) {
+			t.Errorf("%q was logged", leak)
+		}
+	}
+}
+
+func TestRedactorMasksFieldsOfAnyType(t *testing.T) {
+	tests := []struct {
+		redactor *helpscout.Redactor
+		body     string
+		want     string
+	}{
+		{
+			helpscout.NewRedactor(helpscout.DefaultRedactFields...),
+			`{"customer":{"id":3,"firstName":"Jane","lastName":"Doe","phones":[{"value":"555-0100"}],"emails":[{"value":"jane@example.com"}]},"threads":[{"id":7,"text":"hello"}],"subject":"Order"}`,
+			`{"customer":{"id":3,"firstName":"REDACTED","lastName":"REDACTED","phones":"REDACTED","emails":"REDACTED"},"threads":[{"id":7,"text":"REDACTED"}],"subject":"Order"}`,
+		},
+		{
+			helpscout.NewRedactor("customer"),
+			`{"customer":{"firstName":"Jane"},"access_token":"abc","note":"from jane@example.com","subject":"Order"}`,
+			`{"customer":"REDACTED","access_token":"REDACTED","note":"from REDACTED","subject":"Order"}`,
+		},
+		{
+			helpscout.NewRedactor(),
+			`[{"refresh_token":{"value":"abc"}},{"Password":"hunter2"}]`,
+			`[{"refresh_token":"REDACTED"},{"Password":"REDACTED"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		var got, want interface{}
+		if err := json.Unmarshal([]byte(tt.redactor.Redact(tt.body)), &got); err != nil {
+			t.Errorf("%s: %s", tt.body, err)
+			continue
+		}
+		json.Unmarshal([]byte(tt.want), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Redact(%s) = %v, want %v", tt.body, got, want)
+		}
+	}
+}
+
+func TestRedactorMasksText(t *testing.T) {
+	r := helpscout.NewRedactor(helpscout.DefaultRedactFields...)
+	tests := []struct {
+		text string
+		leak string
+	}{
+		{"Authorization: Bearer abc123", "abc123"},
+		{"client_id=id&client_secret=s3cret&grant_type=client_credentials", "s3cret"},
+		{"conversations?query=%28email%3A%22jane%40example.com%22%29", "example.com"},
+		{`truncated {"text": "hello", "id": 7`, "hello"},
+		{"my app secret", "app secret"},
+	}
+
+	for _, tt := range tests {
+		if got := r.Redact(tt.text, "app secret"); strings.Contains(got, tt.leak) {
+			t.Errorf("Redact(%q) = %q, which still has %q", tt.text, got, tt.leak)
+		}
+	}
+}
+
+type secretValuer struct{}
+
+func (secretValuer) LogValue() slog.Value {
+	return slog.StringValue("resolved s3cret")
+}
+
+func TestRedactingHandlerRedactsEveryKind(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(helpscout.NewRedactingHandler(slog.NewJSONHandler(&buf, nil), nil, "s3cret"))
+
+	logger.With(slog.String("from", "jane@example.com")).Info("token s3cret",
+		slog.Any("error", errors.New("failed with s3cret")),
+		slog.Group("customer", slog.String("email", "jane@example.com"), slog.Int("id", 3)),
+		slog.Any("valuer", secretValuer{}),
+		slog.Any("thread", struct {
+			ID   int    `json:"id"`
+			Text string `json:"text"`
+		}{7, "hello"}),
+		slog.Any("token", map[string]string{"access_token": "abc"}),
+	)
+
+	out := buf.String()
+	for _, leak := range []string{"s3cret", "jane@example.com", "hello", "abc"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("%q was logged: %s", leak, out)
+		}
+	}
+	for _, kept := range []string{`"id":3`, `"id":7`} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("%s is missing: %s", kept, out)
+		}
+	}
+}
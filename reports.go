@@ -0,0 +1,338 @@
+package helpscout
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RqReport holds the filters shared by every report endpoint.
+// Start and End are required; PreviousStart and PreviousEnd are optional,
+// but must be given together to get a previous-period comparison
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/
+type RqReport struct {
+	Start         time.Time
+	End           time.Time
+	PreviousStart time.Time
+	PreviousEnd   time.Time
+	Mailboxes     []int
+	Tags          []int
+	Folders       []int
+	Types         []ConversationType
+	OfficeHours   bool
+}
+
+func joinInts(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
+
+// values validates the report request and returns it as query parameters
+func (rq RqReport) values() (v url.Values, err error) {
+	if rq.Start.IsZero() || rq.End.IsZero() {
+		return nil, fmt.Errorf("reports need both a start and end time")
+	}
+	if !rq.End.After(rq.Start) {
+		return nil, fmt.Errorf("report end time must be after its start time")
+	}
+	if rq.PreviousStart.IsZero() != rq.PreviousEnd.IsZero() {
+		return nil, fmt.Errorf("report previous start and end times must be given together")
+	}
+
+	v = url.Values{
+		"start": {Time(rq.Start.UTC()).String()},
+		"end":   {Time(rq.End.UTC()).String()},
+	}
+	if !rq.PreviousStart.IsZero() {
+		if !rq.PreviousEnd.After(rq.PreviousStart) {
+			return nil, fmt.Errorf("report previous end time must be after its previous start time")
+		}
+		v.Set("previousStart", Time(rq.PreviousStart.UTC()).String())
+		v.Set("previousEnd", Time(rq.PreviousEnd.UTC()).String())
+	}
+	if len(rq.Mailboxes) != 0 {
+		v.Set("mailboxes", joinInts(rq.Mailboxes))
+	}
+	if len(rq.Tags) != 0 {
+		v.Set("tags", joinInts(rq.Tags))
+	}
+	if len(rq.Folders) != 0 {
+		v.Set("folders", joinInts(rq.Folders))
+	}
+	if len(rq.Types) != 0 {
+		types := make([]string, len(rq.Types))
+		for i, t := range rq.Types {
+			if !t.Valid() {
+				return nil, fmt.Errorf("%q isn't a valid report conversation type", t)
+			}
+			types[i] = string(t)
+		}
+		v.Set("types", strings.Join(types, ","))
+	}
+	if rq.OfficeHours {
+		v.Set("officeHours", "true")
+	}
+
+	return v, nil
+}
+
+// report fetches the given report endpoint into dest
+func (h *HelpScout) report(name string, rq RqReport, extra url.Values, dest interface{}) (err error) {
+	v, err := rq.values()
+	if err != nil {
+		return
+	}
+	for k, vs := range extra {
+		v[k] = vs
+	}
+
+	_, _, _, err = h.Exec("reports/"+name+"?"+v.Encode(), nil, dest, "")
+	return
+}
+
+// ReportDeltas are the percent changes between the current and previous
+// periods of a report, keyed by the same names as the report's stats
+type ReportDeltas map[string]float64
+
+// ReportRange is the time range a set of report stats covers
+type ReportRange struct {
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+// ReportUser is a user as they appear in report responses
+type ReportUser struct {
+	ID                   int       `json:"id"`
+	Name                 string    `json:"name"`
+	HasPhoto             bool      `json:"hasPhoto"`
+	PhotoURL             string    `json:"photoUrl"`
+	CreatedAt            time.Time `json:"createdAt"`
+	TotalCustomersHelped int       `json:"totalCustomersHelped"`
+}
+
+// ReportTopItem is a single ranked entry in a report's top lists,
+// such as the most used tags or saved replies
+type ReportTopItem struct {
+	ID              int     `json:"id"`
+	Name            string  `json:"name"`
+	Count           int     `json:"count"`
+	PreviousCount   int     `json:"previousCount"`
+	Percent         float64 `json:"percent"`
+	PreviousPercent float64 `json:"previousPercent"`
+	DeltaPercent    float64 `json:"deltaPercent"`
+}
+
+// ReportTopList is a count along with the top entries making it up
+type ReportTopList struct {
+	Count int             `json:"count"`
+	Top   []ReportTopItem `json:"top"`
+}
+
+// ReportCompanyStats are the stats for a single period of the company report
+type ReportCompanyStats struct {
+	ReportRange
+	CustomersHelped int     `json:"customersHelped"`
+	Closed          int     `json:"closed"`
+	TotalReplies    int     `json:"totalReplies"`
+	TotalUsers      int     `json:"totalUsers"`
+	TotalDays       int     `json:"totalDays"`
+	RepliesPerDay   float64 `json:"repliesPerDay"`
+}
+
+// RsCompanyReport is a company overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/company/reports-company-overall/
+type RsCompanyReport struct {
+	Current  ReportCompanyStats  `json:"current"`
+	Previous *ReportCompanyStats `json:"previous"`
+	Deltas   ReportDeltas        `json:"deltas"`
+	Users    []struct {
+		User     ReportUser          `json:"user"`
+		Current  ReportCompanyStats  `json:"current"`
+		Previous *ReportCompanyStats `json:"previous"`
+		Deltas   ReportDeltas        `json:"deltas"`
+	} `json:"users"`
+}
+
+// GetCompanyReport returns the company overview report
+func (h *HelpScout) GetCompanyReport(rq RqReport) (report RsCompanyReport, err error) {
+	err = h.report("company", rq, nil, &report)
+	return
+}
+
+// ReportConversationsStats are the stats for a single period of the
+// conversations report
+type ReportConversationsStats struct {
+	ReportRange
+	TotalConversations   int     `json:"totalConversations"`
+	ConversationsCreated int     `json:"conversationsCreated"`
+	NewConversations     int     `json:"newConversations"`
+	Customers            int     `json:"customers"`
+	ConversationsPerDay  float64 `json:"conversationsPerDay"`
+}
+
+// RsConversationsReport is a conversations overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/conversations/reports-conversations-overall/
+type RsConversationsReport struct {
+	Current    ReportConversationsStats  `json:"current"`
+	Previous   *ReportConversationsStats `json:"previous"`
+	Deltas     ReportDeltas              `json:"deltas"`
+	BusiestDay struct {
+		Day   int `json:"day"`
+		Hour  int `json:"hour"`
+		Count int `json:"count"`
+	} `json:"busiestDay"`
+	Tags      ReportTopList `json:"tags"`
+	Customers ReportTopList `json:"customers"`
+	Replies   ReportTopList `json:"replies"`
+	Workflows ReportTopList `json:"workflows"`
+}
+
+// GetConversationsReport returns the conversations overview report
+func (h *HelpScout) GetConversationsReport(rq RqReport) (report RsConversationsReport, err error) {
+	err = h.report("conversations", rq, nil, &report)
+	return
+}
+
+// ReportProductivityStats are the stats for a single period of the
+// productivity report. Times are in seconds
+type ReportProductivityStats struct {
+	ReportRange
+	TotalConversations          int     `json:"totalConversations"`
+	ResolutionTime              float64 `json:"resolutionTime"`
+	RepliesToResolve            float64 `json:"repliesToResolve"`
+	ResponseTime                float64 `json:"responseTime"`
+	FirstResponseTime           float64 `json:"firstResponseTime"`
+	Resolved                    int     `json:"resolved"`
+	ResolvedOnFirstReply        int     `json:"resolvedOnFirstReply"`
+	PercentResolvedOnFirstReply float64 `json:"percentResolvedOnFirstReply"`
+	Closed                      int     `json:"closed"`
+	RepliesSent                 int     `json:"repliesSent"`
+	HandleTime                  float64 `json:"handleTime"`
+}
+
+// RsProductivityReport is a productivity overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/productivity/reports-productivity-overall/
+type RsProductivityReport struct {
+	Current  ReportProductivityStats  `json:"current"`
+	Previous *ReportProductivityStats `json:"previous"`
+	Deltas   ReportDeltas             `json:"deltas"`
+}
+
+// GetProductivityReport returns the productivity overview report
+func (h *HelpScout) GetProductivityReport(rq RqReport) (report RsProductivityReport, err error) {
+	err = h.report("productivity", rq, nil, &report)
+	return
+}
+
+// ReportUserStats are the stats for a single period of the user report.
+// Times are in seconds
+type ReportUserStats struct {
+	ReportRange
+	TotalDays                   int     `json:"totalDays"`
+	TotalConversations          int     `json:"totalConversations"`
+	ConversationsCreated        int     `json:"conversationsCreated"`
+	ConversationsPerDay         float64 `json:"conversationsPerDay"`
+	CustomersHelped             int     `json:"customersHelped"`
+	Resolved                    int     `json:"resolved"`
+	ResolvedOnFirstReply        int     `json:"resolvedOnFirstReply"`
+	PercentResolvedOnFirstReply float64 `json:"percentResolvedOnFirstReply"`
+	Closed                      int     `json:"closed"`
+	TotalReplies                int     `json:"totalReplies"`
+	RepliesPerDay               float64 `json:"repliesPerDay"`
+	RepliesToResolve            float64 `json:"repliesToResolve"`
+	HandleTime                  float64 `json:"handleTime"`
+	ResponseTime                float64 `json:"responseTime"`
+	ResolutionTime              float64 `json:"resolutionTime"`
+	HappinessScore              float64 `json:"happinessScore"`
+}
+
+// RsUserReport is a user overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/user/reports-user-overall/
+type RsUserReport struct {
+	User     ReportUser       `json:"user"`
+	Current  ReportUserStats  `json:"current"`
+	Previous *ReportUserStats `json:"previous"`
+	Deltas   ReportDeltas     `json:"deltas"`
+}
+
+// GetUserReport returns the overview report for the given user
+func (h *HelpScout) GetUserReport(userID int, rq RqReport) (report RsUserReport, err error) {
+	err = h.report("user", rq, url.Values{"user": {strconv.Itoa(userID)}}, &report)
+	return
+}
+
+// ReportHappinessStats are the stats for a single period of the
+// happiness report
+type ReportHappinessStats struct {
+	ReportRange
+	RatingsCount   int     `json:"ratingsCount"`
+	RatingsPercent float64 `json:"ratingsPercent"`
+	GreatCount     int     `json:"greatCount"`
+	GreatPercent   float64 `json:"greatPercent"`
+	OkayCount      int     `json:"okayCount"`
+	OkayPercent    float64 `json:"okayPercent"`
+	NotGoodCount   int     `json:"notGoodCount"`
+	NotGoodPercent float64 `json:"notGoodPercent"`
+	HappinessScore float64 `json:"happinessScore"`
+}
+
+// RsHappinessReport is a happiness overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/happiness/reports-happiness-overall/
+type RsHappinessReport struct {
+	Current  ReportHappinessStats  `json:"current"`
+	Previous *ReportHappinessStats `json:"previous"`
+	Deltas   ReportDeltas          `json:"deltas"`
+}
+
+// GetHappinessReport returns the happiness overview report
+func (h *HelpScout) GetHappinessReport(rq RqReport) (report RsHappinessReport, err error) {
+	err = h.report("happiness", rq, nil, &report)
+	return
+}
+
+// ReportChannelStats are the stats for a single period of the email,
+// chat, and phone reports. Times are in seconds; not every channel
+// reports every stat
+type ReportChannelStats struct {
+	ReportRange
+	Volume               int     `json:"volume"`
+	ResponseTime         float64 `json:"responseTime"`
+	FirstResponseTime    float64 `json:"firstResponseTime"`
+	ResolveTime          float64 `json:"resolveTime"`
+	Resolved             int     `json:"resolved"`
+	ResolvedOnFirstReply int     `json:"resolvedOnFirstReply"`
+	HandleTime           float64 `json:"handleTime"`
+	WaitTime             float64 `json:"waitTime"`
+	HappinessScore       float64 `json:"happinessScore"`
+}
+
+// RsChannelReport is an email, chat, or phone overview report response
+// https://developer.helpscout.com/mailbox-api/endpoints/reports/email/reports-email-overall/
+type RsChannelReport struct {
+	Current  ReportChannelStats  `json:"current"`
+	Previous *ReportChannelStats `json:"previous"`
+	Deltas   ReportDeltas        `json:"deltas"`
+}
+
+// GetEmailReport returns the email overview report
+func (h *HelpScout) GetEmailReport(rq RqReport) (report RsChannelReport, err error) {
+	err = h.report("email", rq, nil, &report)
+	return
+}
+
+// GetChatReport returns the chat overview report
+func (h *HelpScout) GetChatReport(rq RqReport) (report RsChannelReport, err error) {
+	err = h.report("chat", rq, nil, &report)
+	return
+}
+
+// GetPhoneReport returns the phone overview report
+func (h *HelpScout) GetPhoneReport(rq RqReport) (report RsChannelReport, err error) {
+	err = h.report("phone", rq, nil, &report)
+	return
+}
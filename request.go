@@ -0,0 +1,92 @@
+package helpscout
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Response is the metadata of a Help Scout API response
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+
+	// ResourceID is the ID of the resource a POST created, if any
+	ResourceID int
+
+	// RateLimitRemaining is how many more requests can be made this minute,
+	// or -1 if Help Scout didn't say
+	RateLimitRemaining int
+}
+
+func newResponse(statusCode int, header http.Header, body []byte) *Response {
+	r := &Response{
+		StatusCode:         statusCode,
+		Header:             header,
+		Body:               body,
+		RateLimitRemaining: -1,
+	}
+	if header == nil {
+		return r
+	}
+
+	r.ResourceID, _ = strconv.Atoi(header.Get("Resource-ID"))
+	if remaining, err := strconv.Atoi(header.Get("X-Ratelimit-Remaining-Minute")); err == nil {
+		r.RateLimitRemaining = remaining
+	}
+	return r
+}
+
+// do sends the request through Exec's plumbing and decodes any response body
+// into a T. If the request fails, r still holds the last response, e.g. so
+// Help Scout's error body and logRef can be read
+func do[T any](ctx context.Context, h *HelpScout, method string, path string, body interface{}) (dest T, r *Response, err error) {
+	_, statusCode, header, resp, err := h.exec(ctx, path, body, nil, method)
+	r = newResponse(statusCode, header, resp)
+	if err != nil {
+		return
+	}
+
+	if len(resp) != 0 {
+		err = json.Unmarshal(resp, &dest)
+	}
+	return
+}
+
+// Get sends a GET request for the given path, relative to the API's base URL,
+// and decodes the response into a T
+func Get[T any](ctx context.Context, h *HelpScout, path string, params url.Values) (T, *Response, error) {
+	if len(params) != 0 {
+		if strings.Contains(path, "?") {
+			path += "&" + params.Encode()
+		} else {
+			path += "?" + params.Encode()
+		}
+	}
+	return do[T](ctx, h, "GET", path, nil)
+}
+
+// Post sends body as JSON to the given path and decodes any response into a T
+func Post[T any](ctx context.Context, h *HelpScout, path string, body interface{}) (T, *Response, error) {
+	return do[T](ctx, h, "POST", path, body)
+}
+
+// Put sends body as JSON to the given path and decodes any response into a T
+func Put[T any](ctx context.Context, h *HelpScout, path string, body interface{}) (T, *Response, error) {
+	return do[T](ctx, h, "PUT", path, body)
+}
+
+// Patch sends body as JSON to the given path and decodes any response into a T.
+// Use ApplyPatch to validate a JSONPatch before sending it
+func Patch[T any](ctx context.Context, h *HelpScout, path string, body interface{}) (T, *Response, error) {
+	return do[T](ctx, h, "PATCH", path, body)
+}
+
+// Delete sends a DELETE request for the given path and decodes any response into a T
+func Delete[T any](ctx context.Context, h *HelpScout, path string) (T, *Response, error) {
+	return do[T](ctx, h, "DELETE", path, nil)
+}
@@ -0,0 +1,100 @@
+package helpscout
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SavedReply is a canned response in a mailbox.
+// Listing saved replies only fills in the ID, name, and preview;
+// use GetSavedReply for the text
+// https://developer.helpscout.com/mailbox-api/endpoints/saved-replies/get/
+type SavedReply struct {
+	ID       int    `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Preview  string `json:"preview,omitempty"`
+	Text     string `json:"text,omitempty"`
+	ChatText string `json:"chatText,omitempty"`
+}
+
+func (h *HelpScout) savedRepliesURL() string {
+	return "mailboxes/" + strconv.Itoa(h.MailboxID) + "/saved-replies"
+}
+
+// ListSavedReplies returns all the current mailbox's saved replies
+func (h *HelpScout) ListSavedReplies(includeChatReplies bool) (replies []SavedReply, err error) {
+	u := h.savedRepliesURL()
+	if includeChatReplies {
+		u += "?includeChatReplies=true"
+	}
+
+	_, _, _, err = h.Exec(u, nil, &replies, "")
+	return
+}
+
+// GetSavedReply returns the saved reply with the given ID in the current mailbox
+func (h *HelpScout) GetSavedReply(savedReplyID int) (reply SavedReply, err error) {
+	_, _, _, err = h.Exec(h.savedRepliesURL()+"/"+strconv.Itoa(savedReplyID), nil, &reply, "")
+	return
+}
+
+// GetSavedReplyByName returns the saved reply with the given name in the current mailbox
+func (h *HelpScout) GetSavedReplyByName(name string) (reply SavedReply, err error) {
+	replies, err := h.ListSavedReplies(true)
+	if err != nil {
+		return
+	}
+
+	for _, r := range replies {
+		if r.Name == name {
+			return h.GetSavedReply(r.ID)
+		}
+	}
+
+	return reply, fmt.Errorf("couldn't find the saved reply %q", name)
+}
+
+// NewSavedReply creates a saved reply in the current mailbox and returns its ID
+func (h *HelpScout) NewSavedReply(reply SavedReply) (savedReplyID int, err error) {
+	if len(reply.Name) == 0 {
+		return 0, fmt.Errorf("saved reply names cannot be blank")
+	}
+
+	reply.ID = 0
+	reply.Preview = ""
+	_, header, _, err := h.Exec(h.savedRepliesURL(), reply, nil, "POST")
+	if err != nil {
+		return
+	}
+
+	savedReplyID, _ = strconv.Atoi(header.Get("Resource-ID"))
+	return
+}
+
+// UpdateSavedReply replaces the saved reply with the given reply's ID
+func (h *HelpScout) UpdateSavedReply(reply SavedReply) (err error) {
+	if reply.ID == 0 {
+		return fmt.Errorf("saved reply ID cannot be blank")
+	}
+	if len(reply.Name) == 0 {
+		return fmt.Errorf("saved reply names cannot be blank")
+	}
+
+	u := h.savedRepliesURL() + "/" + strconv.Itoa(reply.ID)
+	reply.ID = 0
+	reply.Preview = ""
+	_, _, _, err = h.Exec(u, reply, nil, "PUT")
+	return
+}
+
+// DeleteSavedReply deletes the saved reply with the given ID in the current mailbox
+func (h *HelpScout) DeleteSavedReply(savedReplyID int) (err error) {
+	_, _, _, err = h.Exec(h.savedRepliesURL()+"/"+strconv.Itoa(savedReplyID), nil, nil, "DELETE")
+	return
+}
+
+// Differs reports whether the content of two saved replies differ,
+// ignoring their IDs and previews
+func (r SavedReply) Differs(other SavedReply) bool {
+	return r.Name != other.Name || r.Text != other.Text || r.ChatText != other.ChatText
+}
@@ -1,6 +1,7 @@
 package helpscout
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -9,19 +10,69 @@ import (
 // NewThread can be though of as a message;
 // Conversations are named literally, and conversations contain threads
 type NewThread struct {
-	Type     string   `json:"type"`
-	Customer Customer `json:"customer"`
-	Content  string   `json:"text"`
-	Imported bool     `json:"imported"`
-	Created  Time     `json:"createdAt"`
+	Type     ThreadType `json:"type"`
+	Customer Customer   `json:"customer"`
+	Content  string     `json:"text"`
+	Imported bool       `json:"imported"`
+	Created  Time       `json:"createdAt"`
+	Draft    bool       `json:"draft,omitempty"`
+	User     int        `json:"user,omitempty"`
+	Cc       []string   `json:"cc,omitempty"`
+	Bcc      []string   `json:"bcc,omitempty"`
+}
+
+// newThreadEndpoints maps thread types to the endpoints that create them
+var newThreadEndpoints = map[ThreadType]string{
+	ThreadTypeCustomer: "customer",
+	ThreadTypeReply:    "reply",
+	ThreadTypeNote:     "notes",
+	ThreadTypeChat:     "chats",
+	ThreadTypePhone:    "phones",
+}
+
+// CreateThread adds the given thread to an existing conversation
+// and returns the new thread's ID
+// https://developer.helpscout.com/mailbox-api/endpoints/conversations/threads/reply/
+func (h *HelpScout) CreateThread(conversationID int, thread NewThread) (threadID int, err error) {
+	err = thread.Validate()
+	if err != nil {
+		return
+	}
+
+	_, header, _, err := h.Exec("conversations/"+strconv.Itoa(conversationID)+"/"+newThreadEndpoints[thread.Type], thread, nil, "POST")
+	if err != nil {
+		return
+	}
+
+	threadID, _ = strconv.Atoi(header.Get("Resource-ID"))
+	return
+}
+
+// Validate checks the thread the same way CreateThread does before sending it
+func (t NewThread) Validate() error {
+	if _, ok := newThreadEndpoints[t.Type]; !ok {
+		return fmt.Errorf("%q threads can't be created", t.Type)
+	}
+	if len(t.Content) == 0 {
+		return fmt.Errorf("thread text cannot be blank")
+	}
+	if t.Type == ThreadTypeReply && !t.Imported && !t.Draft {
+		if t.Customer.ID == 0 && len(t.Customer.Email) == 0 {
+			return fmt.Errorf("live replies need a customer to send to")
+		}
+		if t.User == 0 {
+			return fmt.Errorf("live replies need a user to send from")
+		}
+	}
+	return nil
 }
 
 // Thread is an already existing thread
 type Thread struct {
-	ID     int    `json:"id"`
-	Type   string `json:"type"`
-	Status string `json:"status"`
-	State  string `json:"state"`
+	ID     int         `json:"id"`
+	Type   ThreadType  `json:"type"`
+	Status string      `json:"status"`
+	State  ThreadState `json:"state"`
 	Action struct {
 		Type string `json:"type"`
 		Text string `json:"text"`
@@ -54,6 +105,7 @@ type Thread struct {
 		Email string `json:"email"`
 	} `json:"assignedTo"`
 	SavedReplyID int       `json:"savedReplyId"`
+	RatingID     int       `json:"ratingId"`
 	To           []string  `json:"to"`
 	Cc           []string  `json:"cc"`
 	Bcc          []string  `json:"bcc"`
@@ -87,49 +139,38 @@ type Thread struct {
 		Customer struct {
 			Href string `json:"href"`
 		} `json:"customer"`
-	} `json:"_links"`
-}
-
-type respThreads struct {
-	Embedded struct {
-		Threads []Thread `json:"threads"`
-	} `json:"_embedded"`
-	Links struct {
-		First struct {
-			Href string `json:"href"`
-		} `json:"first"`
-		Last struct {
+		Rating struct {
 			Href string `json:"href"`
-		} `json:"last"`
-		Page struct {
-			Href      string `json:"href"`
-			Templated bool   `json:"templated"`
-		} `json:"page"`
-		Self struct {
-			Href string `json:"href"`
-		} `json:"self"`
+		} `json:"rating"`
 	} `json:"_links"`
-	Page struct {
-		Size          int `json:"size"`
-		TotalElements int `json:"totalElements"`
-		TotalPages    int `json:"totalPages"`
-		Number        int `json:"number"`
-	} `json:"page"`
 }
 
 // GetThreads returns a slice of Threads
 func (h *HelpScout) GetThreads(conversationID int) (threads []Thread, err error) {
-	r, _, _, err := h.Exec(
-		"conversations/"+strconv.Itoa(conversationID)+"/threads",
-		nil,
-		&respThreads{},
-		"",
-	)
+	resp, _, err := Get[Page[Thread]](context.Background(), h, "conversations/"+strconv.Itoa(conversationID)+"/threads", nil)
 	if err != nil {
 		return
 	}
-	resp := r.(*respThreads)
-	return resp.Embedded.Threads, nil
+	return resp.Items()
+}
+
+type respOriginalSource struct {
+	Original string `json:"original"`
+}
+
+// GetOriginalSource returns the parsed original email of the given
+// customer thread. Only threads that came in by email have one
+// https://developer.helpscout.com/mailbox-api/endpoints/conversations/threads/get-source/
+func (h *HelpScout) GetOriginalSource(conversationID int, threadID int) (source *EmailSource, err error) {
+	resp, _, err := Get[respOriginalSource](context.Background(), h, "conversations/"+strconv.Itoa(conversationID)+"/threads/"+strconv.Itoa(threadID)+"/original-source", nil)
+	if err != nil {
+		return
+	}
+	if len(resp.Original) == 0 {
+		return nil, fmt.Errorf("thread %d has no original source", threadID)
+	}
+
+	return ParseEmailSource([]byte(resp.Original))
 }
 
 // GetLatestThreadIDFromThreads takes a Thread slice and returns the ID from the latest one
@@ -179,3 +220,97 @@ func (h *HelpScout) GetEarliestThreadID(conversationID int) (threadID int, err e
 
 	return h.GetEarliestThreadIDFromThreads(threads)
 }
+
+// SendReply adds a live reply from the given user to an existing conversation,
+// which Help Scout emails to the customer, and returns the new thread's ID
+func (h *HelpScout) SendReply(conversationID int, customer Customer, user int, content string) (threadID int, err error) {
+	return h.CreateThread(conversationID, NewThread{
+		Type:     ThreadTypeReply,
+		Customer: Customer{ID: customer.ID, Email: customer.Email},
+		Content:  content,
+		Created:  Time(h.clock().Now().UTC()),
+		User:     user,
+	})
+}
+
+// ThreadNotEditableError is returned when a thread's type doesn't allow an edit
+type ThreadNotEditableError struct {
+	ThreadID int
+	Type     ThreadType
+	Edit     string
+}
+
+func (e *ThreadNotEditableError) Error() string {
+	return fmt.Sprintf("thread %d is a %q thread, which can't have its %s edited", e.ThreadID, e.Type, e.Edit)
+}
+
+// textEditableThreadTypes are the thread types Help Scout allows text edits on
+var textEditableThreadTypes = map[ThreadType]bool{
+	ThreadTypeCustomer: true,
+	ThreadTypeReply:    true,
+	ThreadTypeNote:     true,
+	ThreadTypeChat:     true,
+	ThreadTypePhone:    true,
+}
+
+// hideableThreadTypes are the thread types Help Scout allows hiding
+var hideableThreadTypes = map[ThreadType]bool{
+	ThreadTypeCustomer:      true,
+	ThreadTypeReply:         true,
+	ThreadTypeNote:          true,
+	ThreadTypeChat:          true,
+	ThreadTypePhone:         true,
+	ThreadTypeForwardParent: true,
+	ThreadTypeForwardChild:  true,
+}
+
+// TextEditable reports whether threads of type t can have their text updated
+func (t ThreadType) TextEditable() bool {
+	return textEditableThreadTypes[t]
+}
+
+// Hideable reports whether threads of type t can be hidden
+func (t ThreadType) Hideable() bool {
+	return hideableThreadTypes[t]
+}
+
+func (h *HelpScout) patchThread(conversationID int, threadID int, path string, value interface{}) (err error) {
+	return h.ApplyPatch(
+		"conversations/"+strconv.Itoa(conversationID)+"/threads/"+strconv.Itoa(threadID),
+		NewPatch(PatchThread).Replace(path, value),
+	)
+}
+
+// UpdateThreadText replaces the text of the given thread, e.g. to redact
+// sensitive data. Returns a *ThreadNotEditableError for thread types that
+// can't be edited
+// https://developer.helpscout.com/mailbox-api/endpoints/conversations/threads/update/
+func (h *HelpScout) UpdateThreadText(conversationID int, thread Thread, text string) (err error) {
+	if !thread.Type.TextEditable() {
+		return &ThreadNotEditableError{ThreadID: thread.ID, Type: thread.Type, Edit: "text"}
+	}
+	if len(text) == 0 {
+		return fmt.Errorf("thread text cannot be blank")
+	}
+
+	return h.patchThread(conversationID, thread.ID, "/text", text)
+}
+
+// HideThread hides the given thread in the conversation.
+// Returns a *ThreadNotEditableError for thread types that can't be hidden
+func (h *HelpScout) HideThread(conversationID int, thread Thread) (err error) {
+	return h.setThreadHidden(conversationID, thread, true)
+}
+
+// UnhideThread shows the given previously hidden thread in the conversation
+func (h *HelpScout) UnhideThread(conversationID int, thread Thread) (err error) {
+	return h.setThreadHidden(conversationID, thread, false)
+}
+
+func (h *HelpScout) setThreadHidden(conversationID int, thread Thread, hidden bool) (err error) {
+	if !thread.Type.Hideable() {
+		return &ThreadNotEditableError{ThreadID: thread.ID, Type: thread.Type, Edit: "visibility"}
+	}
+
+	return h.patchThread(conversationID, thread.ID, "/hidden", hidden)
+}
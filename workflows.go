@@ -0,0 +1,92 @@
+package helpscout
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WorkflowRunBatchSize is the maximum number of conversations
+// Help Scout accepts in a single manual workflow run
+const WorkflowRunBatchSize = 50
+
+// Workflow is a Help Scout workflow
+type Workflow struct {
+	ID         int       `json:"id"`
+	MailboxID  int       `json:"mailboxId"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status"`
+	Order      int       `json:"order"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"createdAt"`
+	ModifiedAt time.Time `json:"modifiedAt"`
+}
+
+// RsListWorkflows is a list workflows response
+// https://developer.helpscout.com/mailbox-api/endpoints/workflows/list/
+type RsListWorkflows = Page[Workflow]
+
+// ListWorkflows returns every workflow in the current mailbox, or in
+// every mailbox if none is selected.
+// workflowType filters by "manual" or "automatic"; an empty string returns both
+func (h *HelpScout) ListWorkflows(workflowType string) (workflows []Workflow, err error) {
+	q := url.Values{}
+	if h.MailboxID != 0 {
+		q.Set("mailboxId", strconv.Itoa(h.MailboxID))
+	}
+	if len(workflowType) != 0 {
+		q.Set("type", workflowType)
+	}
+
+	query := "workflows"
+	if len(q) != 0 {
+		query += "?" + q.Encode()
+	}
+	return ListAll[Workflow](h, query)
+}
+
+type reqRunWorkflow struct {
+	ConversationIDs []int `json:"conversationIds"`
+}
+
+// RunManualWorkflow runs the given manual workflow on the given conversations,
+// split into as many requests as needed to stay within WorkflowRunBatchSize
+// https://developer.helpscout.com/mailbox-api/endpoints/workflows/run/
+func (h *HelpScout) RunManualWorkflow(workflowID int, conversationIDs []int) (err error) {
+	if len(conversationIDs) == 0 {
+		return fmt.Errorf("no conversations were given")
+	}
+
+	for i := 0; i < len(conversationIDs); i += WorkflowRunBatchSize {
+		end := i + WorkflowRunBatchSize
+		if end > len(conversationIDs) {
+			end = len(conversationIDs)
+		}
+
+		batch := conversationIDs[i:end]
+		_, _, _, err = h.Exec("workflows/"+strconv.Itoa(workflowID)+"/run", reqRunWorkflow{
+			ConversationIDs: batch,
+		}, nil, "POST")
+		if err != nil {
+			return fmt.Errorf("running workflow %d on conversations %v: %s", workflowID, batch, err)
+		}
+	}
+
+	return
+}
+
+func (h *HelpScout) setWorkflowStatus(workflowID int, status string) (err error) {
+	return h.ApplyPatch("workflows/"+strconv.Itoa(workflowID), NewPatch(PatchWorkflow).Replace("/status", status))
+}
+
+// ActivateWorkflow sets the given workflow's status to active
+// https://developer.helpscout.com/mailbox-api/endpoints/workflows/update-status/
+func (h *HelpScout) ActivateWorkflow(workflowID int) error {
+	return h.setWorkflowStatus(workflowID, "active")
+}
+
+// DeactivateWorkflow sets the given workflow's status to inactive
+func (h *HelpScout) DeactivateWorkflow(workflowID int) error {
+	return h.setWorkflowStatus(workflowID, "inactive")
+}